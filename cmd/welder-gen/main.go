@@ -0,0 +1,128 @@
+// Command welder-gen generates Go structs and typed Encode/Decode helpers from a welder schema,
+// or with `-lang ts` TypeScript declarations of its JSON payload
+//
+// It is meant to be run by go generate, e.g.
+//
+//	//go:generate go run github.com/ideatru/welder/cmd/welder-gen -schema transfer.json -type Transfer -output transfer_gen.go
+//
+// The schema is read from a JSON file of elements (`-` for stdin) or from a Solidity signature
+// such as `transfer(address to, uint256 amount)`, and the package defaults to $GOPACKAGE
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ideatru/welder/codegen"
+	"github.com/ideatru/welder/ether"
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+// integerFormats maps the values of the -integers flag to integer formats
+var integerFormats = map[string]ether.IntegerFormat{
+	"safe":   ether.SafeIntegers,
+	"string": ether.StringIntegers,
+	"number": ether.NumberIntegers,
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "welder-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("welder-gen", flag.ContinueOnError)
+	schemaPath := flags.String("schema", "", "path of the JSON schema file, `-` for stdin")
+	signature := flags.String("signature", "", "Solidity signature to read the schema from, e.g. `transfer(address to, uint256 amount)`")
+	typeName := flags.String("type", "", "name of the generated struct, the capitalized function name by default with -signature")
+	pkg := flags.String("package", os.Getenv("GOPACKAGE"), "package of the generated file, $GOPACKAGE by default")
+	output := flags.String("output", "", "path of the generated file, stdout by default")
+	lang := flags.String("lang", "go", "language of the generated file, `go` or `ts`")
+	integers := flags.String("integers", "safe", "TypeScript type of integers, `safe` (strings above 53 bits), `string` or `number`")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		schema types.Elements
+		err    error
+	)
+
+	switch {
+	case *schemaPath != "" && *signature != "":
+		return fmt.Errorf("-schema and -signature are mutually exclusive")
+	case *signature != "":
+		var name string
+		name, schema, err = ether.ParseSignature(*signature)
+		if err != nil {
+			return err
+		}
+
+		if *typeName == "" {
+			*typeName = utils.ToCamelCase(name)
+		}
+	case *schemaPath != "":
+		schema, err = readSchema(*schemaPath)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("either -schema or -signature is required")
+	}
+
+	var source []byte
+	switch *lang {
+	case "go":
+		source, err = codegen.Generate(schema, codegen.Option{Package: *pkg, Name: *typeName})
+	case "ts":
+		format, ok := integerFormats[*integers]
+		if !ok {
+			return fmt.Errorf("unknown integer format %q", *integers)
+		}
+		source, err = codegen.TypeScript(schema, codegen.TypeScriptOption{Name: *typeName, IntegerFormat: format})
+	default:
+		return fmt.Errorf("unknown language %q", *lang)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+
+	return os.WriteFile(*output, source, 0o644)
+}
+
+// readSchema decodes the schema elements from a JSON file, or from stdin if the path is `-`
+func readSchema(path string) (types.Elements, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var schema types.Elements
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to decode schema %s: %w", path, err)
+	}
+
+	return schema, nil
+}
@@ -0,0 +1,419 @@
+// Command welder encodes, decodes and inspects ABI payloads described by welder schemas
+//
+// Usage:
+//
+//	welder encode   (-schema s.json | -sig 'f(...)') [payload.json]
+//	welder decode   (-schema s.json | -sig 'f(...)') [0x... | data.hex]
+//	welder selector 'transfer(address,uint256)'
+//	welder validate (-schema s.json | -sig 'f(...)') [payload.json]
+//	welder example  (-schema s.json | -sig 'f(...)')
+//
+// Schemas are JSON files of elements, `-` reading them from stdin, or Solidity signatures
+// Payloads and data are read from stdin when omitted, flags may come before or after them
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ideatru/welder"
+	"github.com/ideatru/welder/ether"
+	"github.com/ideatru/welder/types"
+)
+
+// errInvalid is returned by validate once the validation errors are printed
+var errInvalid = errors.New("payload does not match the schema")
+
+// integerFormats maps the values of the -integers flag to integer formats
+var integerFormats = map[string]ether.IntegerFormat{
+	"safe":   ether.SafeIntegers,
+	"string": ether.StringIntegers,
+	"number": ether.NumberIntegers,
+}
+
+// command is a subcommand of the CLI
+type command struct {
+	usage string
+	run   func(c *cli, args []string) error
+}
+
+var commands = map[string]command{
+	"encode":   {"encode ABI data from a JSON payload", (*cli).encode},
+	"decode":   {"decode ABI data into a JSON payload", (*cli).decode},
+	"selector": {"print the 4-byte selector of a function signature", (*cli).selector},
+	"validate": {"check a JSON payload against a schema", (*cli).validate},
+	"example":  {"print an example JSON payload of a schema", (*cli).example},
+}
+
+// cli holds the streams of an invocation
+type cli struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	// stdinRead is set once stdin is consumed, it cannot provide both the schema and the payload
+	stdinRead bool
+}
+
+func main() {
+	c := &cli{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	os.Exit(c.run(os.Args[1:]))
+}
+
+// run dispatches to the subcommand and returns the exit code
+func (c *cli) run(args []string) int {
+	if len(args) == 0 {
+		c.usage()
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(c.stderr, "welder: unknown command %q\n", args[0])
+		c.usage()
+		return 2
+	}
+
+	if err := cmd.run(c, args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintf(c.stderr, "welder %s: %s\n", args[0], err)
+		return 1
+	}
+
+	return 0
+}
+
+// usage prints the list of subcommands
+func (c *cli) usage() {
+	fmt.Fprintln(c.stderr, "usage: welder <command> [flags] [input]")
+	for _, name := range []string{"encode", "decode", "selector", "validate", "example"} {
+		fmt.Fprintf(c.stderr, "  %-9s %s\n", name, commands[name].usage)
+	}
+}
+
+// schemaFlags are the flags shared by the subcommands working on a schema
+type schemaFlags struct {
+	schema       string
+	signature    string
+	zeroOptional bool
+	integers     string
+}
+
+// flags creates the flag set of a subcommand taking a schema
+func (c *cli) flags(name string, sf *schemaFlags) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	flags.StringVar(&sf.schema, "schema", "", "path of the JSON schema file, `-` for stdin")
+	flags.StringVar(&sf.signature, "sig", "", "Solidity signature, e.g. `transfer(address to, uint256 amount)`")
+	flags.BoolVar(&sf.zeroOptional, "zero-optional", false, "encode optional elements as their zero value instead of rejecting them")
+	flags.StringVar(&sf.integers, "integers", "safe", "JSON rendering of decoded integers, `safe`, `string` or `number`")
+	return flags
+}
+
+// load reads the schema and creates the welder configured by the flags
+// The function name is the one of the signature, empty if the schema comes from a file
+// With both -schema and -sig, the signature names the function and must match the schema
+func (c *cli) load(sf *schemaFlags) (*welder.EthereumWelder, string, types.Elements, error) {
+	format, ok := integerFormats[sf.integers]
+	if !ok {
+		return nil, "", nil, fmt.Errorf("unknown integer format %q", sf.integers)
+	}
+
+	opt := welder.Option{IntegerFormat: format}
+	if sf.zeroOptional {
+		opt.OptionalPolicy = ether.ZeroOptional
+	}
+	w := welder.NewEthereum(opt)
+
+	var (
+		name   string
+		schema types.Elements
+	)
+
+	if sf.signature != "" {
+		var err error
+		name, schema, err = ether.ParseSignature(sf.signature)
+		if err != nil {
+			return nil, "", nil, err
+		}
+	}
+
+	switch {
+	case sf.schema != "":
+		data, err := c.read(sf.schema)
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		var fromFile types.Elements
+		if err := json.Unmarshal(data, &fromFile); err != nil {
+			return nil, "", nil, fmt.Errorf("failed to decode schema: %w", err)
+		}
+
+		if sf.signature != "" {
+			if err := sameParameters(schema, fromFile); err != nil {
+				return nil, "", nil, err
+			}
+		}
+		schema = fromFile
+	case sf.signature == "":
+		return nil, "", nil, fmt.Errorf("either -schema or -sig is required")
+	}
+
+	return w, name, schema, nil
+}
+
+// encode welds the payload and prints the ABI encoded data, prefixed with the selector if a function is named
+func (c *cli) encode(args []string) error {
+	var sf schemaFlags
+	flags := c.flags("encode", &sf)
+	args, err := parse(flags, args)
+	if err != nil {
+		return err
+	}
+
+	w, name, schema, err := c.load(&sf)
+	if err != nil {
+		return err
+	}
+
+	payload, err := c.read(first(args))
+	if err != nil {
+		return err
+	}
+
+	abiArgs, err := w.Serialize(schema)
+	if err != nil {
+		return err
+	}
+
+	values, err := w.Weld(schema, payload)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if name != "" {
+		data, err = abiArgs.EncodeCall(name, values...)
+	} else {
+		data, err = abiArgs.Encode(values...)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.stdout, hexutil.Encode(data))
+	return nil
+}
+
+// decode prints the JSON payload of ABI encoded data
+// When a function is named, the data may start with its selector
+func (c *cli) decode(args []string) error {
+	var sf schemaFlags
+	flags := c.flags("decode", &sf)
+	args, err := parse(flags, args)
+	if err != nil {
+		return err
+	}
+
+	w, name, schema, err := c.load(&sf)
+	if err != nil {
+		return err
+	}
+
+	input := first(args)
+	if !strings.HasPrefix(input, "0x") {
+		raw, err := c.read(input)
+		if err != nil {
+			return err
+		}
+		input = string(bytes.TrimSpace(raw))
+	}
+
+	data, err := hexutil.Decode(input)
+	if err != nil {
+		return fmt.Errorf("invalid hex data: %w", err)
+	}
+
+	if name != "" && len(data)%32 == 4 {
+		abiArgs, err := w.Serialize(schema)
+		if err != nil {
+			return err
+		}
+
+		selector, err := abiArgs.Selector(name)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(data[:4], selector) {
+			return fmt.Errorf("selector %s does not match %s", hexutil.Encode(data[:4]), hexutil.Encode(selector))
+		}
+		data = data[4:]
+	}
+
+	result, err := w.Unweld(schema, data)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.stdout, string(result))
+	return nil
+}
+
+// selector prints the 4-byte selector of a function signature
+func (c *cli) selector(args []string) error {
+	flags := flag.NewFlagSet("selector", flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	args, err := parse(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("expected a single function signature")
+	}
+
+	name, schema, err := ether.ParseSignature(args[0])
+	if err != nil {
+		return err
+	}
+
+	abiArgs, err := welder.NewEthereum(welder.Option{OptionalPolicy: ether.ZeroOptional}).Serialize(schema)
+	if err != nil {
+		return err
+	}
+
+	selector, err := abiArgs.Selector(name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.stdout, hexutil.Encode(selector))
+	return nil
+}
+
+// validate prints every mismatch between the payload and the schema
+func (c *cli) validate(args []string) error {
+	var sf schemaFlags
+	flags := c.flags("validate", &sf)
+	args, err := parse(flags, args)
+	if err != nil {
+		return err
+	}
+
+	w, _, schema, err := c.load(&sf)
+	if err != nil {
+		return err
+	}
+
+	payload, err := c.read(first(args))
+	if err != nil {
+		return err
+	}
+
+	errs := w.Validate(schema, payload)
+	for _, e := range errs {
+		fmt.Fprintln(c.stdout, e.Error())
+	}
+
+	if len(errs) > 0 {
+		return errInvalid
+	}
+	return nil
+}
+
+// example prints a payload accepted by encode
+func (c *cli) example(args []string) error {
+	var sf schemaFlags
+	flags := c.flags("example", &sf)
+	args, err := parse(flags, args)
+	if err != nil {
+		return err
+	}
+
+	w, _, schema, err := c.load(&sf)
+	if err != nil {
+		return err
+	}
+
+	payload, err := w.ExampleJSON(schema)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.stdout, string(payload))
+	return nil
+}
+
+// parse parses the flags wherever they appear and returns the positional arguments
+// The flag package stops at the first positional argument, parsing resumes after each of them until `--`
+func parse(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+
+		rest := flags.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// first returns the first positional argument, empty if there is none
+func first(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
+
+// read reads a file, or stdin if the path is empty or `-`
+func (c *cli) read(path string) ([]byte, error) {
+	if path == "" || path == "-" {
+		if c.stdinRead {
+			return nil, fmt.Errorf("stdin is already read, pass the input as a file")
+		}
+		c.stdinRead = true
+		return io.ReadAll(c.stdin)
+	}
+
+	return os.ReadFile(path)
+}
+
+// sameParameters checks that the parameters of a signature have the types of the schema elements
+func sameParameters(signature, schema types.Elements) error {
+	expected, err := ether.FormatParameters(signature)
+	if err != nil {
+		return err
+	}
+
+	actual, err := ether.FormatParameters(schema)
+	if err != nil {
+		return err
+	}
+
+	if expected != actual {
+		return fmt.Errorf("signature parameters %s do not match the schema %s", expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCLI(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.json")
+	assert.NoError(t, os.WriteFile(schemaPath, []byte(`[{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}]`), 0o644))
+
+	payloadPath := filepath.Join(dir, "payload.json")
+	assert.NoError(t, os.WriteFile(payloadPath, []byte(`["0x70997970C51812dc3A010C7d01b50e0d17dc79C8", 1000]`), 0o644))
+
+	const encoded = "0x00000000000000000000000070997970c51812dc3a010c7d01b50e0d17dc79c8" +
+		"00000000000000000000000000000000000000000000000000000000000003e8"
+	calldata := "0xa9059cbb" + encoded[2:]
+
+	type Testcase struct {
+		Name   string
+		Args   []string
+		Stdin  string
+		Code   int
+		Stdout string
+		Stderr string
+	}
+
+	testcases := []Testcase{
+		{
+			Name:   "selector",
+			Args:   []string{"selector", "transfer(address,uint256)"},
+			Stdout: "0xa9059cbb\n",
+		},
+		{
+			Name:   "selector with names",
+			Args:   []string{"selector", "transfer(address to, uint256 amount)"},
+			Stdout: "0xa9059cbb\n",
+		},
+		{
+			Name:   "encode with schema",
+			Args:   []string{"encode", "-schema", schemaPath, payloadPath},
+			Stdout: encoded + "\n",
+		},
+		{
+			Name:   "encode calldata from stdin",
+			Args:   []string{"encode", "-sig", "transfer(address,uint256)"},
+			Stdin:  `["0x70997970C51812dc3A010C7d01b50e0d17dc79C8", 1000]`,
+			Stdout: calldata + "\n",
+		},
+		{
+			Name:   "encode with schema and signature",
+			Args:   []string{"encode", "-schema", schemaPath, "-sig", "transfer(address,uint256)", payloadPath},
+			Stdout: calldata + "\n",
+		},
+		{
+			Name:   "encode with flags after the payload",
+			Args:   []string{"encode", payloadPath, "-sig", "transfer(address,uint256)"},
+			Stdout: calldata + "\n",
+		},
+		{
+			Name:   "encode from stdin with flags after the dash",
+			Args:   []string{"encode", "-", "-schema", schemaPath},
+			Stdin:  `["0x70997970C51812dc3A010C7d01b50e0d17dc79C8", 1000]`,
+			Stdout: encoded + "\n",
+		},
+		{
+			Name:   "decode with flags after the data",
+			Args:   []string{"decode", encoded, "-schema", schemaPath, "-integers", "number"},
+			Stdout: `["0x70997970C51812dc3A010C7d01b50e0d17dc79C8",1000]` + "\n",
+		},
+		{
+			Name:   "positional arguments after a double dash",
+			Args:   []string{"selector", "--", "transfer(address,uint256)"},
+			Stdout: "0xa9059cbb\n",
+		},
+		{
+			Name:   "signature mismatch",
+			Args:   []string{"encode", "-schema", schemaPath, "-sig", "transfer(address,uint128)", payloadPath},
+			Code:   1,
+			Stderr: "welder encode: signature parameters (address,uint128) do not match the schema (address,uint256)\n",
+		},
+		{
+			Name:   "schema and payload from stdin",
+			Args:   []string{"encode", "-schema", "-"},
+			Stdin:  `[{"type": "bool"}]`,
+			Code:   1,
+			Stderr: "welder encode: stdin is already read, pass the input as a file\n",
+		},
+		{
+			Name:   "decode",
+			Args:   []string{"decode", "-schema", schemaPath, encoded},
+			Stdout: `["0x70997970C51812dc3A010C7d01b50e0d17dc79C8","1000"]` + "\n",
+		},
+		{
+			Name:   "decode calldata from stdin",
+			Args:   []string{"decode", "-sig", "transfer(address to, uint256 amount)", "-integers", "number"},
+			Stdin:  calldata + "\n",
+			Stdout: `["0x70997970C51812dc3A010C7d01b50e0d17dc79C8",1000]` + "\n",
+		},
+		{
+			Name:   "decode wrong selector",
+			Args:   []string{"decode", "-sig", "approve(address,uint256)", calldata},
+			Code:   1,
+			Stderr: "welder decode: selector 0xa9059cbb does not match 0x095ea7b3\n",
+		},
+		{
+			Name:   "validate",
+			Args:   []string{"validate", "-schema", schemaPath},
+			Stdin:  `["0x70997970C51812dc3A010C7d01b50e0d17dc79C8", -1]`,
+			Code:   1,
+			Stdout: "/1: value must be between 0 and 115792089237316195423570985008687907853269984665640564039457584007913129639935\n",
+			Stderr: "welder validate: payload does not match the schema\n",
+		},
+		{
+			Name:   "validate valid payload",
+			Args:   []string{"validate", "-schema", schemaPath, payloadPath},
+			Stdout: "",
+		},
+		{
+			Name:   "example",
+			Args:   []string{"example", "-sig", "f(address to, uint8[2] weights)"},
+			Stdout: `["0x0000000000000000000000000000000000000001",[1,1]]` + "\n",
+		},
+		{
+			Name:   "missing schema",
+			Args:   []string{"example"},
+			Code:   1,
+			Stderr: "welder example: either -schema or -sig is required\n",
+		},
+		{
+			Name: "unknown command",
+			Args: []string{"weld"},
+			Code: 2,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			c := &cli{stdin: strings.NewReader(tc.Stdin), stdout: &stdout, stderr: &stderr}
+
+			assert.Equal(t, tc.Code, c.run(tc.Args), stderr.String())
+			if tc.Code == 0 || tc.Stdout != "" {
+				assert.Equal(t, tc.Stdout, stdout.String())
+			}
+			if tc.Stderr != "" {
+				assert.Equal(t, tc.Stderr, stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ideatru/welder/ether"
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+// Option contains configuration options for Generate
+type Option struct {
+	// Package is the name of the package of the generated file
+	Package string
+	// Name is the name of the generated struct, nested structs are prefixed with it
+	Name string
+	// Command is the name of the tool mentioned in the generated header, `welder-gen` by default
+	Command string
+}
+
+// elementTypes maps element types to the name of their constant in the types package
+var elementTypes = map[types.ElementType]string{
+	types.Int:     "types.Int",
+	types.Uint:    "types.Uint",
+	types.Float:   "types.Float",
+	types.String:  "types.String",
+	types.Bytes:   "types.Bytes",
+	types.Address: "types.Address",
+	types.Bool:    "types.Bool",
+	types.Array:   "types.Array",
+	types.Object:  "types.Object",
+}
+
+// Generate emits the Go source of a named struct holding the values of the schema,
+// with typed Encode and Decode helpers backed by ether.AbiElements
+// Objects become named structs with `abi` tags, and field types match the values produced by ABI decoding,
+// e.g. *big.Int for uint256
+// Fields carry no `json` tags since the Go encoding of bytes and big integers is not the one of welder payloads,
+// the root struct marshals to the JSON payload Weld accepts through the Unwelder instead
+// Returns gofmt-ed source or an error if the schema cannot be represented in Go
+func Generate(schema types.Elements, opt Option) ([]byte, error) {
+	if !token.IsIdentifier(opt.Package) {
+		return nil, fmt.Errorf("invalid package name %q", opt.Package)
+	}
+
+	if !token.IsIdentifier(opt.Name) || !token.IsExported(opt.Name) {
+		return nil, fmt.Errorf("type name %q must be an exported identifier", opt.Name)
+	}
+
+	if opt.Command == "" {
+		opt.Command = "welder-gen"
+	}
+
+	// Optional elements are generated as plain values, the ABI has no notion of a missing value
+	if _, err := ether.NewEtherParser(ether.ParserOption{OptionalPolicy: ether.ZeroOptional}).Serialize(schema); err != nil {
+		return nil, err
+	}
+
+	g := &generator{imports: make(map[string]bool)}
+	fields, err := g.fields(opt.Name, topLevel(schema))
+	if err != nil {
+		return nil, err
+	}
+
+	g.structs = append([]goStruct{{name: opt.Name, doc: fmt.Sprintf("%s holds the values of %sSchema", opt.Name, opt.Name), fields: fields}}, g.structs...)
+	g.imports["github.com/ideatru/welder/ether"] = true
+	g.imports["github.com/ideatru/welder/types"] = true
+	if len(schema) > 0 {
+		g.imports["github.com/ethereum/go-ethereum/accounts/abi"] = true
+	}
+
+	source := g.render(schema, opt)
+	formatted, err := format.Source(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// goStruct is a struct declaration of the generated file
+type goStruct struct {
+	name   string
+	doc    string
+	fields []goField
+}
+
+// goField is a field of a generated struct
+type goField struct {
+	name string
+	tag  string
+	ty   string
+}
+
+// generator collects the declarations and imports of the generated file
+type generator struct {
+	structs []goStruct
+	imports map[string]bool
+	schema  bytes.Buffer
+}
+
+// topLevel names unnamed top-level elements `arg0`, `arg1`, ... so that they become struct fields
+func topLevel(schema types.Elements) types.Elements {
+	elements := make(types.Elements, len(schema))
+	for i, elem := range schema {
+		if elem.Name == "" {
+			elem.Name = fmt.Sprintf("arg%d", i)
+		}
+		elements[i] = elem
+	}
+	return elements
+}
+
+// fields converts the children of an object into struct fields, declaring nested structs on the way
+func (g *generator) fields(parent string, children types.Elements) ([]goField, error) {
+	fields := make([]goField, len(children))
+	seen := make(map[string]bool, len(children))
+	for i, child := range children {
+		name := utils.ToCamelCase(child.Name)
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			return nil, fmt.Errorf("field %q of %s cannot be a Go identifier", child.Name, parent)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("fields of %s collide on %s", parent, name)
+		}
+		seen[name] = true
+
+		ty, err := g.goType(child, parent+name)
+		if err != nil {
+			return nil, fmt.Errorf("field %q of %s: %w", child.Name, parent, err)
+		}
+
+		fields[i] = goField{name: name, tag: fmt.Sprintf(`abi:"%s"`, child.Name), ty: ty}
+	}
+
+	return fields, nil
+}
+
+// goType returns the Go type of the values decoded for an element
+// Objects are declared as structs named after their path, e.g. `TransferAccount`
+func (g *generator) goType(elem types.Element, name string) (string, error) {
+	switch elem.Type {
+	case types.String:
+		return "string", nil
+	case types.Bool:
+		return "bool", nil
+	case types.Address:
+		g.imports["github.com/ethereum/go-ethereum/common"] = true
+		return "common.Address", nil
+	case types.Bytes:
+		if elem.Size <= 0 {
+			return "[]byte", nil
+		}
+		return fmt.Sprintf("[%d]byte", elem.Size), nil
+	case types.Int, types.Uint:
+		switch elem.Size {
+		case 8, 16, 32:
+			return fmt.Sprintf("%s%d", elem.Type, elem.Size), nil
+		case 0, 64:
+			return fmt.Sprintf("%s64", elem.Type), nil
+		}
+		g.imports["math/big"] = true
+		return "*big.Int", nil
+	case types.Array:
+		child, err := g.goType(elem.Children[0], name+"Item")
+		if err != nil {
+			return "", err
+		}
+
+		if elem.Size > 0 {
+			return fmt.Sprintf("[%d]%s", elem.Size, child), nil
+		}
+		return "[]" + child, nil
+	case types.Object:
+		fields, err := g.fields(name, elem.Children)
+		if err != nil {
+			return "", err
+		}
+
+		g.structs = append(g.structs, goStruct{name: name, doc: fmt.Sprintf("%s is a tuple of the schema", name), fields: fields})
+		return name, nil
+	}
+
+	return "", fmt.Errorf("code generation does not support %q", elem.Type)
+}
+
+// render writes the generated file before formatting
+func (g *generator) render(schema types.Elements, opt Option) []byte {
+	var buf bytes.Buffer
+	args := strings.ToLower(opt.Name[:1]) + opt.Name[1:] + "Arguments"
+
+	fmt.Fprintf(&buf, "// Code generated by %s. DO NOT EDIT.\n\npackage %s\n\n", opt.Command, opt.Package)
+
+	g.writeSchema(schema)
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		if isStandard(imports[i]) != isStandard(imports[j]) {
+			return isStandard(imports[i])
+		}
+		return imports[i] < imports[j]
+	})
+
+	buf.WriteString("import (\n")
+	for i, path := range imports {
+		// Standard library imports come first, separated from the module imports
+		if i > 0 && isStandard(imports[i-1]) && !isStandard(path) {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "\t%q\n", path)
+	}
+	buf.WriteString(")\n\n")
+
+	fmt.Fprintf(&buf, "// %sSchema is the schema %s is generated from\n", opt.Name, opt.Name)
+	fmt.Fprintf(&buf, "var %sSchema = %s\n\n", opt.Name, g.schema.String())
+
+	fmt.Fprintf(&buf, "// %s are the ABI arguments of %sSchema\n", args, opt.Name)
+	fmt.Fprintf(&buf, "var %s = func() ether.AbiElements {\n", args)
+	fmt.Fprintf(&buf, "\targs, err := ether.NewEtherParser(ether.ParserOption{OptionalPolicy: ether.ZeroOptional}).Serialize(%sSchema)\n", opt.Name)
+	buf.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n\treturn args\n}()\n\n")
+
+	for _, s := range g.structs {
+		fmt.Fprintf(&buf, "// %s\ntype %s struct {\n", s.doc, s.name)
+		for _, field := range s.fields {
+			fmt.Fprintf(&buf, "\t%s %s `%s`\n", field.name, field.ty, field.tag)
+		}
+		buf.WriteString("}\n\n")
+	}
+
+	root := g.structs[0]
+	values := make([]string, len(root.fields))
+	for i, field := range root.fields {
+		values[i] = "v." + field.name
+	}
+
+	buf.WriteString("// Encode packs the values into ABI encoded data\n")
+	fmt.Fprintf(&buf, "func (v *%s) Encode() ([]byte, error) {\n", opt.Name)
+	fmt.Fprintf(&buf, "\treturn %s.Encode(%s)\n}\n\n", args, strings.Join(values, ", "))
+
+	buf.WriteString("// MarshalJSON renders the values as the JSON payload Weld accepts,\n")
+	buf.WriteString("// with hex bytes and integers wider than 53 bits as decimal strings\n")
+	fmt.Fprintf(&buf, "func (v *%s) MarshalJSON() ([]byte, error) {\n", opt.Name)
+	fmt.Fprintf(&buf, "\treturn ether.NewUnwelder(ether.SafeIntegers).Marshal(%sSchema, []any{%s})\n}\n\n", opt.Name, strings.Join(values, ", "))
+
+	fmt.Fprintf(&buf, "// Decode%s unpacks ABI encoded data into a %s\n", opt.Name, opt.Name)
+	fmt.Fprintf(&buf, "func Decode%s(data []byte) (*%s, error) {\n", opt.Name, opt.Name)
+	if len(root.fields) == 0 {
+		fmt.Fprintf(&buf, "\tif _, err := %s.Decode(data); err != nil {\n\t\treturn nil, err\n\t}\n\n", args)
+	} else {
+		fmt.Fprintf(&buf, "\tvalues, err := %s.Decode(data)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\n", args)
+	}
+	fmt.Fprintf(&buf, "\tv := new(%s)\n", opt.Name)
+	for i, field := range root.fields {
+		fmt.Fprintf(&buf, "\tv.%s = *abi.ConvertType(values[%d], new(%s)).(*%s)\n", field.name, i, field.ty, field.ty)
+	}
+	buf.WriteString("\treturn v, nil\n}\n")
+
+	return buf.Bytes()
+}
+
+// writeSchema writes the Go literal of the schema, so that the generated code does not read it at runtime
+func (g *generator) writeSchema(elements types.Elements) {
+	g.schema.WriteString("types.Elements{\n")
+	for _, elem := range elements {
+		parts := make([]string, 0, 6)
+		if elem.Name != "" {
+			parts = append(parts, "Name: "+strconv.Quote(elem.Name))
+		}
+		parts = append(parts, "Type: "+elementTypes[elem.Type])
+		if elem.Size != 0 {
+			parts = append(parts, "Size: "+strconv.Itoa(elem.Size))
+		}
+		if elem.Optional {
+			parts = append(parts, "Optional: true")
+		}
+		if elem.Indexed {
+			parts = append(parts, "Indexed: true")
+		}
+		if elem.Default != nil {
+			g.imports["encoding/json"] = true
+			parts = append(parts, "Default: json.RawMessage("+strconv.Quote(string(elem.Default))+")")
+		}
+
+		g.schema.WriteString("{" + strings.Join(parts, ", "))
+		if len(elem.Children) > 0 {
+			g.schema.WriteString(", Children: ")
+			g.writeSchema(elem.Children)
+		}
+		g.schema.WriteString("},\n")
+	}
+	g.schema.WriteString("}")
+}
+
+// isStandard reports whether the import path belongs to the standard library
+func isStandard(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
@@ -0,0 +1,113 @@
+package codegen
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("generated package is up to date", func(t *testing.T) {
+		data, err := os.ReadFile("internal/generated/transfer.json")
+		assert.NoError(t, err)
+
+		var schema types.Elements
+		assert.NoError(t, json.Unmarshal(data, &schema))
+
+		source, err := Generate(schema, Option{Package: "generated", Name: "Transfer"})
+		assert.NoError(t, err)
+
+		expected, err := os.ReadFile("internal/generated/transfer_gen.go")
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(source), "run go generate ./codegen/...")
+	})
+
+	type Testcase struct {
+		Name     string
+		Schema   types.Elements
+		Option   Option
+		Contains []string
+		Error    string
+	}
+
+	testcases := []Testcase{
+		{
+			Name:   "unnamed elements",
+			Schema: types.Elements{{Type: types.Uint, Size: 256}, {Type: types.Int}},
+			Option: Option{Package: "abi", Name: "Pair"},
+			Contains: []string{
+				"Arg0 *big.Int `abi:\"arg0\"`",
+				"Arg1 int64    `abi:\"arg1\"`",
+				"return pairArguments.Encode(v.Arg0, v.Arg1)",
+				"return ether.NewUnwelder(ether.SafeIntegers).Marshal(PairSchema, []any{v.Arg0, v.Arg1})",
+			},
+		},
+		{
+			Name: "nested objects",
+			Schema: types.Elements{{Name: "order", Type: types.Object, Children: types.Elements{
+				{Name: "maker_address", Type: types.Address},
+				{Name: "fees", Type: types.Array, Size: 2, Children: types.Elements{
+					{Type: types.Object, Children: types.Elements{{Name: "bps", Type: types.Uint, Size: 16}}},
+				}},
+			}}},
+			Option: Option{Package: "orders", Name: "Fill", Command: "make generate"},
+			Contains: []string{
+				"// Code generated by make generate. DO NOT EDIT.",
+				"Order FillOrder `abi:\"order\"`",
+				"type FillOrder struct {",
+				"MakerAddress common.Address       `abi:\"maker_address\"`",
+				"Fees         [2]FillOrderFeesItem `abi:\"fees\"`",
+				"type FillOrderFeesItem struct {",
+			},
+		},
+		{
+			Name:     "empty schema",
+			Schema:   types.Elements{},
+			Option:   Option{Package: "empty", Name: "Nothing"},
+			Contains: []string{"type Nothing struct {\n}", "return nothingArguments.Encode()"},
+		},
+		{
+			Name:   "invalid package",
+			Schema: types.Elements{{Type: types.Bool}},
+			Option: Option{Package: "my-package", Name: "Flag"},
+			Error:  `invalid package name "my-package"`,
+		},
+		{
+			Name:   "unexported name",
+			Schema: types.Elements{{Type: types.Bool}},
+			Option: Option{Package: "flags", Name: "flag"},
+			Error:  `type name "flag" must be an exported identifier`,
+		},
+		{
+			Name:   "colliding fields",
+			Schema: types.Elements{{Name: "owner_id", Type: types.Bool}, {Name: "ownerId", Type: types.Bool}},
+			Option: Option{Package: "flags", Name: "Flags"},
+			Error:  "fields of Flags collide on OwnerId",
+		},
+		{
+			Name:   "unsupported type",
+			Schema: types.Elements{{Name: "ratio", Type: types.Float}},
+			Option: Option{Package: "ratios", Name: "Ratio"},
+			Error:  "float",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			source, err := Generate(tc.Schema, tc.Option)
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+				return
+			}
+
+			assert.NoError(t, err)
+			for _, fragment := range tc.Contains {
+				assert.True(t, strings.Contains(string(source), fragment), "missing %q in\n%s", fragment, source)
+			}
+		})
+	}
+}
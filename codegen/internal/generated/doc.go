@@ -0,0 +1,5 @@
+// Package generated holds code produced by welder-gen, keeping the generator output compiling and round-tripping
+package generated
+
+//go:generate go run ../../../cmd/welder-gen -schema transfer.json -type Transfer -output transfer_gen.go
+//go:generate go run ../../../cmd/welder-gen -lang ts -schema transfer.json -type Transfer -output transfer.ts
@@ -0,0 +1,91 @@
+// Code generated by welder-gen. DO NOT EDIT.
+
+package generated
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ideatru/welder/ether"
+	"github.com/ideatru/welder/types"
+)
+
+// TransferSchema is the schema Transfer is generated from
+var TransferSchema = types.Elements{
+	{Name: "from", Type: types.Address},
+	{Name: "recipients", Type: types.Array, Children: types.Elements{
+		{Type: types.Object, Children: types.Elements{
+			{Name: "to", Type: types.Address},
+			{Name: "amount", Type: types.Uint, Size: 256},
+			{Name: "memo", Type: types.String, Optional: true},
+		}},
+	}},
+	{Name: "nonce", Type: types.Uint, Size: 64, Default: json.RawMessage("0")},
+	{Name: "weights", Type: types.Array, Size: 3, Children: types.Elements{
+		{Type: types.Uint, Size: 8},
+	}},
+	{Name: "salt", Type: types.Bytes, Size: 32},
+	{Name: "payload", Type: types.Bytes},
+	{Name: "delta", Type: types.Int, Size: 128},
+	{Name: "signed", Type: types.Bool},
+}
+
+// transferArguments are the ABI arguments of TransferSchema
+var transferArguments = func() ether.AbiElements {
+	args, err := ether.NewEtherParser(ether.ParserOption{OptionalPolicy: ether.ZeroOptional}).Serialize(TransferSchema)
+	if err != nil {
+		panic(err)
+	}
+	return args
+}()
+
+// Transfer holds the values of TransferSchema
+type Transfer struct {
+	From       common.Address           `abi:"from"`
+	Recipients []TransferRecipientsItem `abi:"recipients"`
+	Nonce      uint64                   `abi:"nonce"`
+	Weights    [3]uint8                 `abi:"weights"`
+	Salt       [32]byte                 `abi:"salt"`
+	Payload    []byte                   `abi:"payload"`
+	Delta      *big.Int                 `abi:"delta"`
+	Signed     bool                     `abi:"signed"`
+}
+
+// TransferRecipientsItem is a tuple of the schema
+type TransferRecipientsItem struct {
+	To     common.Address `abi:"to"`
+	Amount *big.Int       `abi:"amount"`
+	Memo   string         `abi:"memo"`
+}
+
+// Encode packs the values into ABI encoded data
+func (v *Transfer) Encode() ([]byte, error) {
+	return transferArguments.Encode(v.From, v.Recipients, v.Nonce, v.Weights, v.Salt, v.Payload, v.Delta, v.Signed)
+}
+
+// MarshalJSON renders the values as the JSON payload Weld accepts,
+// with hex bytes and integers wider than 53 bits as decimal strings
+func (v *Transfer) MarshalJSON() ([]byte, error) {
+	return ether.NewUnwelder(ether.SafeIntegers).Marshal(TransferSchema, []any{v.From, v.Recipients, v.Nonce, v.Weights, v.Salt, v.Payload, v.Delta, v.Signed})
+}
+
+// DecodeTransfer unpacks ABI encoded data into a Transfer
+func DecodeTransfer(data []byte) (*Transfer, error) {
+	values, err := transferArguments.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	v := new(Transfer)
+	v.From = *abi.ConvertType(values[0], new(common.Address)).(*common.Address)
+	v.Recipients = *abi.ConvertType(values[1], new([]TransferRecipientsItem)).(*[]TransferRecipientsItem)
+	v.Nonce = *abi.ConvertType(values[2], new(uint64)).(*uint64)
+	v.Weights = *abi.ConvertType(values[3], new([3]uint8)).(*[3]uint8)
+	v.Salt = *abi.ConvertType(values[4], new([32]byte)).(*[32]byte)
+	v.Payload = *abi.ConvertType(values[5], new([]byte)).(*[]byte)
+	v.Delta = *abi.ConvertType(values[6], new(*big.Int)).(**big.Int)
+	v.Signed = *abi.ConvertType(values[7], new(bool)).(*bool)
+	return v, nil
+}
@@ -0,0 +1,85 @@
+package generated
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ideatru/welder"
+	"github.com/ideatru/welder/ether"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransfer(t *testing.T) {
+	transfer := &Transfer{
+		From: common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"),
+		Recipients: []TransferRecipientsItem{
+			{To: common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), Amount: big.NewInt(1000000000000000000), Memo: "rent"},
+			{To: common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"), Amount: big.NewInt(5)},
+		},
+		Nonce:   7,
+		Weights: [3]uint8{1, 2, 3},
+		Salt:    [32]byte{31: 1},
+		Payload: []byte{0xca, 0xfe},
+		Delta:   big.NewInt(-42),
+		Signed:  true,
+	}
+
+	data, err := transfer.Encode()
+	assert.NoError(t, err)
+
+	// The TypeScript declaration types integers wider than 53 bits as decimal strings, welding accepts both forms
+	payloads := map[string][]byte{
+		"numbers": []byte(`["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", [
+			{"to": "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", "amount": 1000000000000000000, "memo": "rent"},
+			{"to": "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC", "amount": 5}
+		], 7, [1, 2, 3], "0x0000000000000000000000000000000000000000000000000000000000000001", "0xcafe", -42, true]`),
+		"typescript": []byte(`["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", [
+			{"to": "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", "amount": "1000000000000000000", "memo": "rent"},
+			{"to": "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC", "amount": "5", "memo": null}
+		], "7", [1, 2, 3], "0x0000000000000000000000000000000000000000000000000000000000000001", "0xcafe", "-42", true]`),
+	}
+
+	for name, payload := range payloads {
+		t.Run("matches welding "+name, func(t *testing.T) {
+			w := welder.NewEthereum(welder.Option{OptionalPolicy: ether.ZeroOptional})
+			args, err := w.Serialize(TransferSchema)
+			assert.NoError(t, err)
+
+			params, err := w.Weld(TransferSchema, payload)
+			assert.NoError(t, err)
+
+			expected, err := args.Encode(params...)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, data)
+		})
+	}
+
+	t.Run("marshals to a welding payload", func(t *testing.T) {
+		payload, err := json.Marshal(transfer)
+		assert.NoError(t, err)
+
+		w := welder.NewEthereum(welder.Option{OptionalPolicy: ether.ZeroOptional})
+		args, err := w.Serialize(TransferSchema)
+		assert.NoError(t, err)
+
+		params, err := w.Weld(TransferSchema, payload)
+		assert.NoError(t, err)
+
+		expected, err := args.Encode(params...)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, data)
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		decoded, err := DecodeTransfer(data)
+		assert.NoError(t, err)
+		assert.Equal(t, transfer, decoded)
+	})
+
+	t.Run("decode invalid data", func(t *testing.T) {
+		_, err := DecodeTransfer(data[:40])
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,216 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ideatru/welder/ether"
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+// maxTupleSize is the largest fixed-size array rendered as a TypeScript tuple, bigger ones are plain arrays
+const maxTupleSize = 16
+
+// maxSafeIntegerBits is the width of the largest integer a JavaScript number represents exactly
+const maxSafeIntegerBits = 53
+
+// hexType is the template literal type of addresses and bytes
+const hexType = "`0x${string}`"
+
+// identifierRe matches property names that do not need quoting in TypeScript
+var identifierRe = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// TypeScriptOption contains configuration options for TypeScript
+type TypeScriptOption struct {
+	// Name is the name of the generated payload type, nested interfaces are prefixed with it
+	Name string
+	// IntegerFormat defines how integers are typed, following the JSON rendered by ether.Unwelder
+	// With the default SafeIntegers, integers wider than 53 bits are decimal strings
+	IntegerFormat ether.IntegerFormat
+	// Command is the name of the tool mentioned in the generated header, `welder-gen` by default
+	Command string
+}
+
+// TypeScript emits TypeScript declarations describing the JSON payload of the schema
+// The payload is a labelled tuple, objects become exported interfaces named after their path,
+// addresses and bytes are `0x${string}` and wide integers are strings
+// Optional elements accept null, and trailing elements that may be omitted are optional tuple members
+// Returns the source or an error if the schema cannot be represented in TypeScript
+func TypeScript(schema types.Elements, opt TypeScriptOption) ([]byte, error) {
+	if !token.IsIdentifier(opt.Name) {
+		return nil, fmt.Errorf("invalid type name %q", opt.Name)
+	}
+
+	if opt.Command == "" {
+		opt.Command = "welder-gen"
+	}
+
+	g := &tsGenerator{opt: opt, names: map[string]bool{opt.Name: true}}
+	elements := topLevel(schema)
+
+	// Only trailing elements that can be left out of the payload are optional tuple members
+	required := 0
+	for i, elem := range elements {
+		if !elem.Optional && elem.Default == nil {
+			required = i + 1
+		}
+	}
+
+	members := make([]string, len(elements))
+	for i, elem := range elements {
+		ty, err := g.tsType(elem, opt.Name+utils.ToCamelCase(elem.Name))
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+
+		label := elem.Name
+		if !identifierRe.MatchString(label) {
+			label = fmt.Sprintf("arg%d", i)
+		}
+		if i >= required {
+			label += "?"
+		}
+		members[i] = fmt.Sprintf("  %s: %s,\n", label, ty)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by %s. DO NOT EDIT.\n\n", opt.Command)
+	fmt.Fprintf(&buf, "export type %s = [\n%s];\n", opt.Name, strings.Join(members, ""))
+	for _, declaration := range g.declarations {
+		buf.WriteString("\n")
+		buf.WriteString(declaration)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// tsGenerator collects the interfaces declared for the objects of a schema
+type tsGenerator struct {
+	opt          TypeScriptOption
+	declarations []string
+	names        map[string]bool
+}
+
+// tsType returns the TypeScript type of the JSON value of an element
+// Optional elements additionally accept null
+func (g *tsGenerator) tsType(elem types.Element, name string) (string, error) {
+	ty, err := g.tsElementType(elem, name)
+	if err != nil {
+		return "", err
+	}
+
+	if elem.Optional {
+		return ty + " | null", nil
+	}
+
+	return ty, nil
+}
+
+// tsElementType dispatches to the appropriate type based on the element type
+func (g *tsGenerator) tsElementType(elem types.Element, name string) (string, error) {
+	switch elem.Type {
+	case types.String:
+		return "string", nil
+	case types.Bool:
+		return "boolean", nil
+	case types.Float:
+		return "number", nil
+	case types.Address, types.Bytes:
+		return hexType, nil
+	case types.Int, types.Uint:
+		return g.tsInteger(elem), nil
+	case types.Array:
+		return g.tsArray(elem, name)
+	case types.Object:
+		return g.tsObject(elem, name)
+	}
+
+	return "", fmt.Errorf("typescript generation does not support %q", elem.Type)
+}
+
+// tsInteger types integers as numbers or decimal strings depending on the integer format
+func (g *tsGenerator) tsInteger(elem types.Element) string {
+	size := elem.Size
+	if size == 0 {
+		size = 64
+	}
+
+	switch {
+	case g.opt.IntegerFormat == ether.StringIntegers:
+		return "string"
+	case g.opt.IntegerFormat == ether.SafeIntegers && size > maxSafeIntegerBits:
+		return "string"
+	}
+
+	return "number"
+}
+
+// tsArray types dynamic arrays as `T[]` and small fixed-size arrays as tuples
+// Fixed-size arrays whose items have a default may be shorter, so they stay plain arrays
+func (g *tsGenerator) tsArray(elem types.Element, name string) (string, error) {
+	if len(elem.Children) != 1 {
+		return "", fmt.Errorf("array must have one child")
+	}
+
+	child, err := g.tsType(elem.Children[0], name+"Item")
+	if err != nil {
+		return "", err
+	}
+
+	if elem.Size > 0 && elem.Size <= maxTupleSize && elem.Children[0].Default == nil {
+		items := make([]string, elem.Size)
+		for i := range items {
+			items[i] = child
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	}
+
+	if strings.Contains(child, " | ") {
+		child = "(" + child + ")"
+	}
+	return child + "[]", nil
+}
+
+// tsObject declares an interface for the object and returns its name
+// Children that are optional or have a default may be omitted
+func (g *tsGenerator) tsObject(elem types.Element, name string) (string, error) {
+	if len(elem.Children) == 0 {
+		return "", fmt.Errorf("object must have at least one child")
+	}
+
+	if g.names[name] {
+		return "", fmt.Errorf("interface name %s is declared twice", name)
+	}
+	g.names[name] = true
+
+	// Reserve the position so that interfaces are declared parent first
+	index := len(g.declarations)
+	g.declarations = append(g.declarations, "")
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "export interface %s {\n", name)
+	for _, child := range elem.Children {
+		ty, err := g.tsType(child, name+utils.ToCamelCase(child.Name))
+		if err != nil {
+			return "", fmt.Errorf("field %q: %w", child.Name, err)
+		}
+
+		key := child.Name
+		if !identifierRe.MatchString(key) {
+			key = strconv.Quote(key)
+		}
+		if child.Optional || child.Default != nil {
+			key += "?"
+		}
+		fmt.Fprintf(&buf, "  %s: %s;\n", key, ty)
+	}
+	buf.WriteString("}\n")
+
+	g.declarations[index] = buf.String()
+	return name, nil
+}
@@ -0,0 +1,103 @@
+package codegen
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/ideatru/welder/ether"
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeScript(t *testing.T) {
+	t.Run("generated declarations are up to date", func(t *testing.T) {
+		data, err := os.ReadFile("internal/generated/transfer.json")
+		assert.NoError(t, err)
+
+		var schema types.Elements
+		assert.NoError(t, json.Unmarshal(data, &schema))
+
+		source, err := TypeScript(schema, TypeScriptOption{Name: "Transfer"})
+		assert.NoError(t, err)
+
+		expected, err := os.ReadFile("internal/generated/transfer.ts")
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(source), "run go generate ./codegen/...")
+	})
+
+	type Testcase struct {
+		Name     string
+		Schema   types.Elements
+		Option   TypeScriptOption
+		Expected string
+		Error    string
+	}
+
+	testcases := []Testcase{
+		{
+			Name:   "unnamed elements and trailing optionals",
+			Schema: types.Elements{{Type: types.Uint, Size: 32}, {Type: types.Bool, Optional: true}, {Type: types.String, Default: json.RawMessage(`"x"`)}},
+			Option: TypeScriptOption{Name: "Call"},
+			Expected: "// Code generated by welder-gen. DO NOT EDIT.\n\n" +
+				"export type Call = [\n  arg0: number,\n  arg1?: boolean | null,\n  arg2?: string,\n];\n",
+		},
+		{
+			Name: "nested objects and arrays",
+			Schema: types.Elements{{Name: "order", Type: types.Object, Children: types.Elements{
+				{Name: "maker-address", Type: types.Address},
+				{Name: "tags", Type: types.Array, Children: types.Elements{{Type: types.Bytes, Size: 4, Optional: true}}},
+				{Name: "fees", Type: types.Array, Size: 20, Children: types.Elements{
+					{Type: types.Object, Children: types.Elements{{Name: "bps", Type: types.Uint, Size: 16, Default: json.RawMessage(`0`)}}},
+				}},
+			}}},
+			Option: TypeScriptOption{Name: "Fill", Command: "make generate"},
+			Expected: "// Code generated by make generate. DO NOT EDIT.\n\n" +
+				"export type Fill = [\n  order: FillOrder,\n];\n\n" +
+				"export interface FillOrder {\n  \"maker-address\": `0x${string}`;\n  tags: (`0x${string}` | null)[];\n  fees: FillOrderFeesItem[];\n}\n\n" +
+				"export interface FillOrderFeesItem {\n  bps?: number;\n}\n",
+		},
+		{
+			Name:   "string integers",
+			Schema: types.Elements{{Name: "small", Type: types.Int, Size: 8}},
+			Option: TypeScriptOption{Name: "Small", IntegerFormat: ether.StringIntegers},
+			Expected: "// Code generated by welder-gen. DO NOT EDIT.\n\n" +
+				"export type Small = [\n  small: string,\n];\n",
+		},
+		{
+			Name:   "number integers",
+			Schema: types.Elements{{Name: "wide", Type: types.Uint, Size: 256}},
+			Option: TypeScriptOption{Name: "Wide", IntegerFormat: ether.NumberIntegers},
+			Expected: "// Code generated by welder-gen. DO NOT EDIT.\n\n" +
+				"export type Wide = [\n  wide: number,\n];\n",
+		},
+		{
+			Name:   "invalid name",
+			Schema: types.Elements{{Type: types.Bool}},
+			Option: TypeScriptOption{Name: "my-type"},
+			Error:  `invalid type name "my-type"`,
+		},
+		{
+			Name: "colliding interfaces",
+			Schema: types.Elements{{Name: "a", Type: types.Object, Children: types.Elements{
+				{Name: "b_c", Type: types.Object, Children: types.Elements{{Name: "x", Type: types.Bool}}},
+				{Name: "bC", Type: types.Object, Children: types.Elements{{Name: "y", Type: types.Bool}}},
+			}}},
+			Option: TypeScriptOption{Name: "Pair"},
+			Error:  "interface name PairABC is declared twice",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			source, err := TypeScript(tc.Schema, tc.Option)
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, string(source))
+		})
+	}
+}
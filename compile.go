@@ -0,0 +1,117 @@
+package welder
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/ideatru/welder/ether"
+	"github.com/ideatru/welder/types"
+)
+
+// CompiledSchema is a schema whose Go types and ABI arguments are built once and reused for every payload.
+// It is immutable and safe for concurrent use, the schema is copied when compiled.
+type CompiledSchema struct {
+	schema   types.Elements
+	types    []reflect.Type
+	args     ether.AbiElements
+	unwelder *ether.Unwelder
+
+	// selectors caches the selector of every function name passed to EncodeCall
+	selectors sync.Map
+}
+
+// Compile builds the Go types and ABI arguments of the schema with a welder configured by the options.
+func Compile(schema types.Elements, opts ...Option) (*CompiledSchema, error) {
+	return NewEthereum(opts...).Compile(schema)
+}
+
+// Compile builds the Go types and ABI arguments of the schema with the configuration of the welder.
+// The schema is deep-copied, modifying it afterwards does not affect the compiled schema.
+// Returns an error if the schema cannot be built or serialized.
+func (w *EthereumWelder) Compile(schema types.Elements) (*CompiledSchema, error) {
+	tys, err := w.builder.BuildTypes(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := w.Serialize(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompiledSchema{
+		schema:   schema.Clone(),
+		types:    tys,
+		args:     args,
+		unwelder: w.unwelder,
+	}, nil
+}
+
+// Schema returns a copy of the compiled schema.
+func (c *CompiledSchema) Schema() types.Elements {
+	return c.schema.Clone()
+}
+
+// Arguments returns the ABI arguments of the schema.
+func (c *CompiledSchema) Arguments() ether.AbiElements {
+	return c.args
+}
+
+// Weld unmarshals data into new values of the compiled types, the same way as EthereumWelder.Weld.
+func (c *CompiledSchema) Weld(data []byte) ([]any, error) {
+	values := make([]any, len(c.types))
+	for i, ty := range c.types {
+		values[i] = reflect.New(ty).Interface()
+	}
+
+	if err := weldPayload(c.schema, data, values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// Encode welds the JSON payload and packs it into ABI encoded data.
+func (c *CompiledSchema) Encode(data []byte) ([]byte, error) {
+	values, err := c.Weld(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.args.Encode(values...)
+}
+
+// EncodeCall welds the JSON payload and packs it into calldata of the named function, selector included.
+func (c *CompiledSchema) EncodeCall(functionName string, data []byte) ([]byte, error) {
+	selector, err := c.selector(functionName)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := c.Encode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append(make([]byte, 0, len(selector)+len(encoded)), selector...), encoded...), nil
+}
+
+// Unweld decodes ABI encoded data into a JSON array, the same way as EthereumWelder.Unweld.
+func (c *CompiledSchema) Unweld(data []byte) ([]byte, error) {
+	return c.unwelder.Unweld(c.args, c.schema, data)
+}
+
+// selector returns the selector of the named function taking the schema, computing it once per name.
+func (c *CompiledSchema) selector(functionName string) ([]byte, error) {
+	if selector, ok := c.selectors.Load(functionName); ok {
+		return selector.([]byte), nil
+	}
+
+	selector, err := c.args.Selector(functionName)
+	if err != nil {
+		return nil, err
+	}
+
+	c.selectors.Store(functionName, selector)
+	return selector, nil
+}
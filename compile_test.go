@@ -0,0 +1,199 @@
+package welder_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ideatru/welder"
+	"github.com/ideatru/welder/ether"
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var benchmarkSchema = types.Elements{
+	{Type: types.String},
+	{
+		Type: types.Object,
+		Children: types.Elements{
+			{Type: types.Address, Name: "owner"},
+			{Type: types.String, Name: "name"},
+			{Type: types.Array, Name: "balances", Children: types.Elements{
+				{Type: types.Object, Children: types.Elements{
+					{Type: types.Uint, Size: 256, Name: "amount"},
+					{Type: types.String, Name: "currency"},
+				}},
+			}},
+			{Type: types.Bytes, Size: 4, Name: "tag"},
+		},
+	},
+}
+
+var benchmarkPayload = []byte(`["Hello, World!!!",{"owner": "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "name": "Ether", "balances": [{"amount": 1000000000000000000, "currency": "ETH"}, {"amount": 5, "currency": "WEI"}], "tag": "0x01020304"}]`)
+
+func TestCompile(t *testing.T) {
+	w := welder.NewEthereum()
+	compiled, err := w.Compile(benchmarkSchema)
+	assert.NoError(t, err)
+	assert.Equal(t, benchmarkSchema, compiled.Schema())
+
+	args, err := w.Serialize(benchmarkSchema)
+	assert.NoError(t, err)
+
+	params, err := w.Weld(benchmarkSchema, benchmarkPayload)
+	assert.NoError(t, err)
+
+	expected, err := args.Encode(params...)
+	assert.NoError(t, err)
+
+	t.Run("weld", func(t *testing.T) {
+		values, err := compiled.Weld(benchmarkPayload)
+		assert.NoError(t, err)
+		assert.Equal(t, params, values)
+	})
+
+	t.Run("encode", func(t *testing.T) {
+		data, err := compiled.Encode(benchmarkPayload)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, data)
+	})
+
+	t.Run("encode call", func(t *testing.T) {
+		call, err := args.EncodeCall("getData", params...)
+		assert.NoError(t, err)
+
+		for range 2 {
+			data, err := compiled.EncodeCall("getData", benchmarkPayload)
+			assert.NoError(t, err)
+			assert.Equal(t, call, data)
+		}
+	})
+
+	t.Run("unweld", func(t *testing.T) {
+		unwelded, err := compiled.Unweld(expected)
+		assert.NoError(t, err)
+
+		// uint256 values are rendered as decimal strings by default and welded back as is
+		data, err := compiled.Encode(unwelded)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, data)
+
+		numbers, err := welder.Compile(benchmarkSchema, welder.Option{IntegerFormat: ether.NumberIntegers})
+		assert.NoError(t, err)
+
+		unwelded, err = numbers.Unweld(expected)
+		assert.NoError(t, err)
+
+		data, err = numbers.Encode(unwelded)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, data)
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		var wg sync.WaitGroup
+		results := make([][]byte, 16)
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], _ = compiled.EncodeCall("getData", benchmarkPayload)
+			}()
+		}
+		wg.Wait()
+
+		for _, data := range results {
+			assert.Equal(t, results[0], data)
+		}
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		_, err := compiled.Encode([]byte(`["Hello"]`))
+		assert.EqualError(t, err, "/1: object is required")
+	})
+
+	t.Run("schema copied", func(t *testing.T) {
+		schema := types.Elements{
+			{Name: "owner", Type: types.Address},
+			{Name: "limit", Type: types.Uint, Size: 8, Default: []byte(`7`)},
+			{Name: "tags", Type: types.Array, Children: types.Elements{{Type: types.Bytes, Size: 4}}},
+		}
+		payload := []byte(`["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", null, ["0x01020304"]]`)
+
+		copied, err := welder.Compile(schema)
+		assert.NoError(t, err)
+
+		before, err := copied.Encode(payload)
+		assert.NoError(t, err)
+
+		// Neither the compiled schema nor the copy returned by Schema share memory with the caller
+		schema[1].Default[0] = '9'
+		schema[2].Children[0].Size = 2
+		returned := copied.Schema()
+		returned[0].Type = types.String
+		returned[2].Children[0].Size = 8
+
+		after, err := copied.Encode(payload)
+		assert.NoError(t, err)
+		assert.Equal(t, before, after)
+		assert.Equal(t, types.Address, copied.Schema()[0].Type)
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		_, err := welder.Compile(types.Elements{{Type: types.Object}})
+		assert.Error(t, err)
+
+		_, err = welder.Compile(types.Elements{{Type: types.String, Optional: true}})
+		assert.Error(t, err)
+	})
+}
+
+func BenchmarkWeldEncode(b *testing.B) {
+	w := welder.NewEthereum()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		args, err := w.Serialize(benchmarkSchema)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		params, err := w.Weld(benchmarkSchema, benchmarkPayload)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if _, err := args.Encode(params...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompiledEncode(b *testing.B) {
+	compiled, err := welder.Compile(benchmarkSchema)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := compiled.Encode(benchmarkPayload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompiledEncodeParallel(b *testing.B) {
+	compiled, err := welder.Compile(benchmarkSchema)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := compiled.Encode(benchmarkPayload); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,341 @@
+package welder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ideatru/welder/ether"
+	"github.com/ideatru/welder/types"
+)
+
+// ContractFunction describes a function of a contract with the schemas of its inputs and outputs.
+type ContractFunction struct {
+	// Name is the function name, shared by all overloads
+	Name string
+	// Signature is the canonical signature, e.g. `transfer(address,uint256)`
+	Signature string
+	// Selector is the 4-byte selector prepended to calldata
+	Selector []byte
+	// StateMutability is one of `pure`, `view`, `nonpayable` or `payable`
+	StateMutability string
+	// Inputs is the schema of the function arguments
+	Inputs types.Elements
+	// Outputs is the schema of the function return values
+	Outputs types.Elements
+}
+
+// ContractEvent describes an event of a contract with the schema of its inputs.
+type ContractEvent struct {
+	// Name is the event name, shared by all overloads
+	Name string
+	// Signature is the canonical signature, e.g. `Transfer(address,address,uint256)`
+	Signature string
+	// ID is the keccak256 hash of the signature, emitted as topic0 unless the event is anonymous
+	ID common.Hash
+	// Anonymous reports whether the event omits its ID from the topics
+	Anonymous bool
+	// Inputs is the schema of the event arguments
+	Inputs types.Elements
+}
+
+// Event returns the ether.Event used to decode logs of the event.
+func (e *ContractEvent) Event() ether.Event {
+	return ether.Event{Name: e.Name, Anonymous: e.Anonymous, Inputs: e.Inputs}
+}
+
+// ContractError describes a custom error of a contract with the schema of its inputs.
+type ContractError struct {
+	// Name is the error name
+	Name string
+	// Signature is the canonical signature, e.g. `InsufficientBalance(uint256,uint256)`
+	Signature string
+	// Selector is the 4-byte selector prepended to the revert data
+	Selector []byte
+	// Inputs is the schema of the error arguments
+	Inputs types.Elements
+}
+
+// Contract is a registry of the functions, events and errors declared by a Solidity ABI.
+// Overloaded members are addressable by their full signature.
+type Contract struct {
+	welder    *EthereumWelder
+	functions map[string]*ContractFunction
+	events    map[string]*ContractEvent
+	errors    map[string]*ContractError
+	reverts   *ether.RevertDecoder
+}
+
+// NewContract creates a Contract from a standard Solidity ABI JSON document using the default EthereumWelder.
+func NewContract(data []byte) (*Contract, error) {
+	return NewEthereum().Contract(data)
+}
+
+// Contract creates a Contract from a standard Solidity ABI JSON document.
+// Returns an error if the document is invalid or contains types the welder cannot represent.
+func (w *EthereumWelder) Contract(data []byte) (*Contract, error) {
+	parsed, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse contract ABI: %w", err)
+	}
+
+	contract := &Contract{
+		welder:    w,
+		functions: make(map[string]*ContractFunction, len(parsed.Methods)),
+		events:    make(map[string]*ContractEvent, len(parsed.Events)),
+		errors:    make(map[string]*ContractError),
+		reverts:   w.RevertDecoder(),
+	}
+
+	for _, method := range parsed.Methods {
+		inputs, err := w.Deserialize(ether.AbiElements(method.Inputs))
+		if err != nil {
+			return nil, fmt.Errorf("function %q: %w", method.Sig, err)
+		}
+
+		outputs, err := w.Deserialize(ether.AbiElements(method.Outputs))
+		if err != nil {
+			return nil, fmt.Errorf("function %q: %w", method.Sig, err)
+		}
+
+		contract.functions[method.Sig] = &ContractFunction{
+			Name:            method.RawName,
+			Signature:       method.Sig,
+			Selector:        method.ID,
+			StateMutability: method.StateMutability,
+			Inputs:          inputs,
+			Outputs:         outputs,
+		}
+	}
+
+	for _, event := range parsed.Events {
+		inputs, err := w.Deserialize(ether.AbiElements(event.Inputs))
+		if err != nil {
+			return nil, fmt.Errorf("event %q: %w", event.Sig, err)
+		}
+
+		contract.events[event.Sig] = &ContractEvent{
+			Name:      event.RawName,
+			Signature: event.Sig,
+			ID:        event.ID,
+			Anonymous: event.Anonymous,
+			Inputs:    inputs,
+		}
+	}
+
+	abiErrors, err := parseErrors(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse contract ABI: %w", err)
+	}
+
+	for _, abiErr := range abiErrors {
+		if _, ok := contract.errors[abiErr.Sig]; ok {
+			continue
+		}
+
+		inputs, err := w.Deserialize(ether.AbiElements(abiErr.Inputs))
+		if err != nil {
+			return nil, fmt.Errorf("error %q: %w", abiErr.Sig, err)
+		}
+
+		contract.errors[abiErr.Sig] = &ContractError{
+			Name:      abiErr.Name,
+			Signature: abiErr.Sig,
+			Selector:  abiErr.ID[:4],
+			Inputs:    inputs,
+		}
+
+		if err := contract.reverts.Register(abiErr.Name, inputs); err != nil {
+			return nil, fmt.Errorf("error %q: %w", abiErr.Sig, err)
+		}
+	}
+
+	return contract, nil
+}
+
+// parseErrors parses every custom error of a Solidity ABI JSON document.
+// abi.JSON keys errors by name, so that overloaded errors would replace each other: each one is parsed on its own.
+// Errors inherited by several contracts may be declared more than once with the same signature.
+func parseErrors(data []byte) ([]abi.Error, error) {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	errs := make([]abi.Error, 0)
+	for _, entry := range entries {
+		var field struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(entry, &field); err != nil {
+			return nil, err
+		}
+
+		if field.Type != "error" {
+			continue
+		}
+
+		parsed, err := abi.JSON(bytes.NewReader(append(append([]byte("["), entry...), ']')))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, abiErr := range parsed.Errors {
+			errs = append(errs, abiErr)
+		}
+	}
+
+	return errs, nil
+}
+
+// Functions returns every function of the contract sorted by signature.
+func (c *Contract) Functions() []*ContractFunction { return sorted(c.functions) }
+
+// Events returns every event of the contract sorted by signature.
+func (c *Contract) Events() []*ContractEvent { return sorted(c.events) }
+
+// Errors returns every custom error of the contract sorted by signature.
+func (c *Contract) Errors() []*ContractError { return sorted(c.errors) }
+
+// Function looks a function up by name, or by full signature when the name is overloaded.
+func (c *Contract) Function(name string) (*ContractFunction, error) {
+	return lookup(c.functions, "function", name, func(fn *ContractFunction) string { return fn.Name })
+}
+
+// Event looks an event up by name, or by full signature when the name is overloaded.
+func (c *Contract) Event(name string) (*ContractEvent, error) {
+	return lookup(c.events, "event", name, func(event *ContractEvent) string { return event.Name })
+}
+
+// Error looks a custom error up by name or by full signature.
+func (c *Contract) Error(name string) (*ContractError, error) {
+	return lookup(c.errors, "error", name, func(err *ContractError) string { return err.Name })
+}
+
+// Weld welds the JSON payload into the arguments of the function and returns the calldata.
+// The function is looked up by name, or by full signature when the name is overloaded.
+func (c *Contract) Weld(name string, data []byte) ([]byte, error) {
+	fn, err := c.Function(name)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := c.welder.Serialize(fn.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := c.welder.Weld(fn.Inputs, data)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := args.Encode(values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %q: %w", fn.Signature, err)
+	}
+
+	return append(append([]byte{}, fn.Selector...), encoded...), nil
+}
+
+// Unweld decodes the return data of the function into a JSON array keyed by its output names.
+// The function is looked up by name, or by full signature when the name is overloaded.
+func (c *Contract) Unweld(name string, data []byte) ([]byte, error) {
+	fn, err := c.Function(name)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.welder.Unweld(fn.Outputs, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %q: %w", fn.Signature, err)
+	}
+
+	return result, nil
+}
+
+// UnweldEvent decodes a log into a JSON object keyed by the input names of the event it belongs to.
+// The event is identified by topic0, so logs of anonymous events cannot be decoded this way.
+func (c *Contract) UnweldEvent(topics [][32]byte, data []byte) (*ContractEvent, []byte, error) {
+	if len(topics) == 0 {
+		return nil, nil, fmt.Errorf("log has no topics")
+	}
+
+	for _, event := range c.events {
+		if event.Anonymous || event.ID != topics[0] {
+			continue
+		}
+
+		result, err := c.welder.UnweldEvent(event.Event(), topics, data)
+		if err != nil {
+			return nil, nil, err
+		}
+		return event, result, nil
+	}
+
+	return nil, nil, fmt.Errorf("contract has no event with ID %s", common.Hash(topics[0]).Hex())
+}
+
+// DecodeRevert decodes the data of a reverted call into an *ether.RevertError.
+// The builtin `Error(string)` and `Panic(uint256)` are recognized along with the custom errors of the contract.
+func (c *Contract) DecodeRevert(data []byte) (*ether.RevertError, error) {
+	return c.reverts.Decode(data)
+}
+
+// lookup finds a member by its full signature, or by name if the name is not overloaded
+// Signatures are canonicalized first, so `transfer(address to, uint amount)` matches `transfer(address,uint256)`
+func lookup[T any](members map[string]*T, kind, name string, nameOf func(*T) string) (*T, error) {
+	name = strings.TrimSpace(name)
+	if strings.Contains(name, "(") {
+		fnName, params, err := ether.ParseSignature(name)
+		if err != nil {
+			return nil, err
+		}
+
+		signature, err := ether.FormatSignature(fnName, params)
+		if err != nil {
+			return nil, err
+		}
+
+		if member, ok := members[signature]; ok {
+			return member, nil
+		}
+		return nil, fmt.Errorf("contract has no %s %q", kind, signature)
+	}
+
+	candidates := make([]string, 0, 1)
+	for signature, member := range members {
+		if nameOf(member) == name {
+			candidates = append(candidates, signature)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("contract has no %s %q", kind, name)
+	case 1:
+		return members[candidates[0]], nil
+	}
+
+	sort.Strings(candidates)
+	return nil, fmt.Errorf("%s %q is overloaded, use one of %s", kind, name, strings.Join(candidates, ", "))
+}
+
+// sorted returns the members of a registry sorted by signature
+func sorted[T any](members map[string]*T) []*T {
+	signatures := make([]string, 0, len(members))
+	for signature := range members {
+		signatures = append(signatures, signature)
+	}
+	sort.Strings(signatures)
+
+	result := make([]*T, len(signatures))
+	for i, signature := range signatures {
+		result[i] = members[signature]
+	}
+	return result
+}
@@ -0,0 +1,158 @@
+package welder_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ideatru/welder"
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var contractABI = []byte(`[
+	{"type": "function", "name": "transfer", "stateMutability": "nonpayable",
+	 "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}],
+	 "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "safeTransferFrom", "stateMutability": "nonpayable",
+	 "inputs": [{"name": "from", "type": "address"}, {"name": "to", "type": "address"}, {"name": "tokenId", "type": "uint256"}],
+	 "outputs": []},
+	{"type": "function", "name": "safeTransferFrom", "stateMutability": "nonpayable",
+	 "inputs": [{"name": "from", "type": "address"}, {"name": "to", "type": "address"}, {"name": "tokenId", "type": "uint256"}, {"name": "data", "type": "bytes"}],
+	 "outputs": []},
+	{"type": "function", "name": "getData", "stateMutability": "view",
+	 "inputs": [{"name": "key", "type": "string"}, {"name": "account", "type": "tuple", "components": [
+		{"name": "owner", "type": "address"},
+		{"name": "balance", "type": "tuple", "components": [{"name": "amount", "type": "uint256"}, {"name": "currency", "type": "string"}]}
+	 ]}],
+	 "outputs": [{"name": "values", "type": "uint256[]"}]},
+	{"type": "event", "name": "Transfer", "anonymous": false,
+	 "inputs": [{"name": "from", "type": "address", "indexed": true}, {"name": "to", "type": "address", "indexed": true}, {"name": "value", "type": "uint256", "indexed": false}]},
+	{"type": "error", "name": "InsufficientBalance",
+	 "inputs": [{"name": "available", "type": "uint256"}, {"name": "required", "type": "uint256"}]}
+]`)
+
+func TestContract(t *testing.T) {
+	contract, err := welder.NewContract(contractABI)
+	assert.NoError(t, err)
+
+	t.Run("schemas", func(t *testing.T) {
+		fn, err := contract.Function("getData")
+		assert.NoError(t, err)
+		assert.Equal(t, "getData(string,(address,(uint256,string)))", fn.Signature)
+		assert.Equal(t, types.Elements{
+			{Name: "key", Type: types.String},
+			{Name: "account", Type: types.Object, Children: types.Elements{
+				{Name: "owner", Type: types.Address},
+				{Name: "balance", Type: types.Object, Children: types.Elements{{Name: "amount", Type: types.Uint, Size: 256}, {Name: "currency", Type: types.String}}},
+			}},
+		}, fn.Inputs)
+		assert.Equal(t, types.Elements{{Name: "values", Type: types.Array, Children: types.Elements{{Type: types.Uint, Size: 256}}}}, fn.Outputs)
+
+		event, err := contract.Event("Transfer")
+		assert.NoError(t, err)
+		assert.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", event.ID.Hex())
+
+		abiErr, err := contract.Error("InsufficientBalance")
+		assert.NoError(t, err)
+		assert.Equal(t, "InsufficientBalance(uint256,uint256)", abiErr.Signature)
+
+		assert.Len(t, contract.Functions(), 4)
+	})
+
+	t.Run("overloads", func(t *testing.T) {
+		_, err := contract.Function("safeTransferFrom")
+		assert.ErrorContains(t, err, "overloaded")
+
+		fn, err := contract.Function("safeTransferFrom(address from, address to, uint tokenId, bytes data)")
+		assert.NoError(t, err)
+		assert.Equal(t, "safeTransferFrom(address,address,uint256,bytes)", fn.Signature)
+
+		_, err = contract.Function("safeTransferFrom(address)")
+		assert.Error(t, err)
+	})
+
+	t.Run("weld", func(t *testing.T) {
+		data, err := contract.Weld("transfer", []byte(`["0xB035aD4B31759d909178d32da02266BD199c7e15", 1]`))
+		assert.NoError(t, err)
+		assert.Equal(t, hexutil.MustDecode("0xa9059cbb000000000000000000000000b035ad4b31759d909178d32da02266bd199c7e150000000000000000000000000000000000000000000000000000000000000001"), data)
+
+		_, err = contract.Weld("getData", []byte(`["key", {"owner": "0xB035aD4B31759d909178d32da02266BD199c7e15", "balance": {"amount": 10, "currency": "ETH"}}]`))
+		assert.NoError(t, err)
+
+		_, err = contract.Weld("approve", []byte(`[]`))
+		assert.Error(t, err)
+	})
+
+	t.Run("unweld", func(t *testing.T) {
+		data := hexutil.MustDecode("0x000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000001ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+		actual, err := contract.Unweld("getData", data)
+		assert.NoError(t, err)
+		assert.Equal(t, `[["1","115792089237316195423570985008687907853269984665640564039457584007913129639935"]]`, string(actual))
+
+		_, err = contract.Unweld("getData", data[:32])
+		assert.Error(t, err)
+	})
+
+	t.Run("events", func(t *testing.T) {
+		event, err := contract.Event("Transfer")
+		assert.NoError(t, err)
+		assert.True(t, event.Inputs[0].Indexed)
+		assert.False(t, event.Inputs[2].Indexed)
+
+		topics := [][32]byte{
+			event.ID,
+			common.HexToHash("0x000000000000000000000000f39fd6e51aad88f6f4ce6ab8827279cfffb92266"),
+			common.HexToHash("0x000000000000000000000000b035ad4b31759d909178d32da02266bd199c7e15"),
+		}
+		data := hexutil.MustDecode("0x00000000000000000000000000000000000000000000000000000000000003e8")
+
+		matched, actual, err := contract.UnweldEvent(topics, data)
+		assert.NoError(t, err)
+		assert.Equal(t, "Transfer(address,address,uint256)", matched.Signature)
+		assert.Equal(t, `{"from":"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266","to":"0xB035aD4B31759d909178d32da02266BD199c7e15","value":"1000"}`, string(actual))
+
+		_, _, err = contract.UnweldEvent([][32]byte{{}}, data)
+		assert.Error(t, err)
+	})
+
+	t.Run("reverts", func(t *testing.T) {
+		revert, err := contract.DecodeRevert(hexutil.MustDecode("0xcf47918100000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002"))
+		assert.NoError(t, err)
+		assert.Equal(t, "InsufficientBalance", revert.Name)
+		assert.EqualError(t, revert, `execution reverted: InsufficientBalance {"available":"1","required":"2"}`)
+
+		revert, err = contract.DecodeRevert(hexutil.MustDecode("0x4e487b710000000000000000000000000000000000000000000000000000000000000001"))
+		assert.NoError(t, err)
+		assert.Equal(t, "assertion failed", revert.Reason)
+	})
+}
+
+func TestContract_OverloadedErrors(t *testing.T) {
+	contract, err := welder.NewContract([]byte(`[
+		{"type": "error", "name": "Unauthorized", "inputs": []},
+		{"type": "error", "name": "Unauthorized", "inputs": [{"name": "account", "type": "address"}]},
+		{"type": "error", "name": "Unauthorized", "inputs": [{"name": "account", "type": "address"}]}
+	]`))
+	assert.NoError(t, err)
+	assert.Len(t, contract.Errors(), 2)
+
+	_, err = contract.Error("Unauthorized")
+	assert.Error(t, err)
+
+	for _, signature := range []string{"Unauthorized()", "Unauthorized(address)"} {
+		abiErr, err := contract.Error(signature)
+		assert.NoError(t, err)
+		assert.Equal(t, "Unauthorized", abiErr.Name)
+
+		data := append([]byte{}, abiErr.Selector...)
+		if len(abiErr.Inputs) > 0 {
+			data = append(data, common.LeftPadBytes(common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266").Bytes(), 32)...)
+		}
+
+		revert, err := contract.DecodeRevert(data)
+		assert.NoError(t, err)
+		assert.Equal(t, "Unauthorized", revert.Name)
+		assert.Equal(t, signature, revert.Signature)
+	}
+}
@@ -0,0 +1,17 @@
+package welder
+
+import (
+	"github.com/ideatru/welder/ether"
+	"github.com/ideatru/welder/types"
+)
+
+// EncodeValues packs loosely typed values, such as maps and slices decoded from JSON, straight into ABI encoded data.
+// It skips the Go types built by Weld and the strict type matching of AbiElements.Encode, see ether.Encoder.
+// Returns an error if the schema cannot be serialized with the configuration of the welder.
+func (w *EthereumWelder) EncodeValues(schema types.Elements, values ...any) ([]byte, error) {
+	if _, err := w.Serialize(schema); err != nil {
+		return nil, err
+	}
+
+	return ether.EncodeValues(schema, values...)
+}
@@ -0,0 +1,76 @@
+package welder_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ideatru/welder"
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEthereumWelder_EncodeValues(t *testing.T) {
+	w := welder.NewEthereum()
+
+	args, err := w.Serialize(benchmarkSchema)
+	assert.NoError(t, err)
+
+	params, err := w.Weld(benchmarkSchema, benchmarkPayload)
+	assert.NoError(t, err)
+
+	expected, err := args.Encode(params...)
+	assert.NoError(t, err)
+
+	// Amounts beyond 2^53 lose precision as float64, decode numbers as json.Number
+	decoder := json.NewDecoder(bytes.NewReader(benchmarkPayload))
+	decoder.UseNumber()
+
+	var values []any
+	assert.NoError(t, decoder.Decode(&values))
+
+	actual, err := w.EncodeValues(benchmarkSchema, values...)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	_, err = w.EncodeValues(types.Elements{{Type: types.Array}}, values...)
+	assert.Error(t, err)
+}
+
+func TestEthereumWelder_EncodeUnknownFields(t *testing.T) {
+	schema := types.Elements{
+		{Type: types.Object, Children: types.Elements{
+			{Name: "owner", Type: types.Address},
+			{Name: "balance", Type: types.Object, Children: types.Elements{{Name: "amount", Type: types.Uint, Size: 256}}},
+		}},
+	}
+	payload := []byte(`[{"owner": "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "note": "ignored", "balance": {"amount": "1000000000000000000", "currency": {"code": "ETH"}}}]`)
+
+	w := welder.NewEthereum()
+	args, err := w.Serialize(schema)
+	assert.NoError(t, err)
+
+	// Unknown keys are ignored by Weld, direct and stream encoding must produce the same data
+	params, err := w.Weld(schema, payload)
+	assert.NoError(t, err)
+
+	expected, err := args.Encode(params...)
+	assert.NoError(t, err)
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var values []any
+	assert.NoError(t, decoder.Decode(&values))
+
+	actual, err := w.EncodeValues(schema, values...)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	encoder, err := w.NewStreamEncoder(schema, bytes.NewReader(payload))
+	assert.NoError(t, err)
+
+	actual, err = encoder.Encode()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
@@ -1,8 +1,6 @@
 package welder
 
 import (
-	"encoding/json"
-
 	"github.com/ideatru/welder/ether"
 	"github.com/ideatru/welder/internal/builder"
 	"github.com/ideatru/welder/types"
@@ -15,15 +13,35 @@ func NewEthereumBuilder() *builder.Builder {
 
 // EthereumWelder implements the types.Welder interface for Ethereum ABI.
 type EthereumWelder struct {
-	parser  types.Parser[ether.AbiElements]
-	builder *builder.Builder
+	parser    types.Parser[ether.AbiElements]
+	builder   *builder.Builder
+	cleanser  *ether.Cleanser
+	validator *ether.Validator
+	unwelder  *ether.Unwelder
+}
+
+// Option contains configuration options for the EthereumWelder.
+type Option struct {
+	// OptionalPolicy defines how optional elements are serialized into Ethereum ABI.
+	OptionalPolicy ether.OptionalPolicy
+	// IntegerFormat defines how Unweld renders integers in JSON.
+	IntegerFormat ether.IntegerFormat
 }
 
-// NewEthereum creates a new EthereumWelder with default configuration.
-func NewEthereum() *EthereumWelder {
+// NewEthereum creates a new EthereumWelder.
+// If no options are provided, the default configuration is used.
+func NewEthereum(opts ...Option) *EthereumWelder {
+	var opt Option
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
 	return &EthereumWelder{
-		parser:  ether.NewEtherParser(),
-		builder: NewEthereumBuilder(),
+		parser:    ether.NewEtherParser(ether.ParserOption{OptionalPolicy: opt.OptionalPolicy}),
+		builder:   NewEthereumBuilder(),
+		cleanser:  ether.NewCleanser(),
+		validator: ether.NewValidator(),
+		unwelder:  ether.NewUnwelder(opt.IntegerFormat),
 	}
 }
 
@@ -37,20 +55,64 @@ func (w *EthereumWelder) Serialize(elements types.Elements) (ether.AbiElements,
 	return w.parser.Serialize(elements)
 }
 
+// Cleanse normalizes the JSON payload into the canonical form accepted by Weld.
+// Returns the cleansed payload together with every rewrite that was applied.
+func (w *EthereumWelder) Cleanse(schema types.Elements, data []byte) ([]byte, []ether.Rewrite, error) {
+	return w.cleanser.Cleanse(schema, data)
+}
+
+// Validate checks the JSON payload against the schema without welding it.
+// Returns every mismatch addressed by its JSON pointer, or an empty slice if the payload is valid.
+func (w *EthereumWelder) Validate(schema types.Elements, data []byte) []ether.ValidationError {
+	return w.validator.Validate(schema, data)
+}
+
 // Weld builds Go types from the schema and unmarshals data into them.
+// Integers are checked against the declared size and signedness of their element,
+// and only optional elements may be missing or null in the payload.
 func (w *EthereumWelder) Weld(schema types.Elements, data []byte) ([]any, error) {
 	result, err := w.builder.Builds(schema)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := json.Unmarshal(data, &result); err != nil {
+	if err := weldPayload(schema, data, result); err != nil {
 		return nil, err
 	}
 
 	return result, nil
 }
 
+// Unweld decodes ABI encoded data according to the schema and renders it as a JSON array.
+// It is the mirror of Weld: objects are keyed by the schema names, integers follow the
+// configured IntegerFormat, bytes are rendered as hex and addresses are checksummed.
+func (w *EthereumWelder) Unweld(schema types.Elements, data []byte) ([]byte, error) {
+	args, err := w.Serialize(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.unwelder.Unweld(args, schema, data)
+}
+
+// UnweldEvent decodes the topics and data of a log emitted by the event into a JSON object.
+// Topic0 must match the event signature hash unless the event is anonymous, and indexed
+// inputs of reference types are rendered as their keccak256 hash.
+func (w *EthereumWelder) UnweldEvent(event ether.Event, topics [][32]byte, data []byte) ([]byte, error) {
+	return w.unwelder.UnweldEvent(event, topics, data)
+}
+
+// RevertDecoder creates a decoder of revert data rendering integers with the configured IntegerFormat.
+// Custom errors must be registered on the decoder before their revert data can be decoded.
+func (w *EthereumWelder) RevertDecoder() *ether.RevertDecoder {
+	return ether.NewRevertDecoder(w.unwelder.IntegerFormat)
+}
+
+// ExampleJSON renders a deterministic, human-readable payload for the schema that Weld accepts.
+func (w *EthereumWelder) ExampleJSON(schema types.Elements) ([]byte, error) {
+	return ether.ExampleJSON(schema)
+}
+
 // Builder returns the underlying builder instance.
 func (w *EthereumWelder) Builder() *builder.Builder {
 	return w.builder
@@ -0,0 +1,347 @@
+package ether
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+// RewriteKind describes the kind of normalization applied to a value
+type RewriteKind string
+
+const (
+	// RewriteTrim reports that leading or trailing whitespace was removed
+	RewriteTrim = RewriteKind("trim")
+	// RewriteNumber reports that a numeric string, hex quantity or exponent was coerced into a decimal integer
+	RewriteNumber = RewriteKind("number")
+	// RewriteBool reports that a "true"/"false" string was coerced into a boolean
+	RewriteBool = RewriteKind("bool")
+	// RewriteChecksum reports that an address was converted to its EIP-55 checksum form
+	RewriteChecksum = RewriteKind("checksum")
+	// RewriteHex reports that a byte string was normalized to lower-case hex with a `0x` prefix
+	RewriteHex = RewriteKind("hex")
+	// RewriteDrop reports that a value unknown to the schema was removed
+	RewriteDrop = RewriteKind("drop")
+)
+
+// Rewrite records a single change applied to the payload by the Cleanser
+type Rewrite struct {
+	// Path is the JSON pointer of the rewritten value, e.g. `/1/balance/amount`
+	Path string `json:"path"`
+	// Kind is the kind of normalization applied
+	Kind RewriteKind `json:"kind"`
+	// Before is the value prior to the rewrite
+	Before any `json:"before"`
+	// After is the value after the rewrite, nil if the value was dropped
+	After any `json:"after"`
+}
+
+// Cleanser normalizes JSON payloads into the canonical form expected by welding
+// It walks the schema alongside the payload and coerces every value it can
+type Cleanser struct{}
+
+// NewCleanser creates a new instance of Cleanser
+func NewCleanser() *Cleanser { return &Cleanser{} }
+
+// Cleanse walks the schema alongside the JSON payload and returns the canonical JSON
+// Numbers become decimal integers, addresses are checksummed, bytes are 0x-prefixed hex,
+// strings are trimmed and object keys unknown to the schema are dropped
+// Returns every rewrite applied, or an error if a value cannot be coerced
+func (c *Cleanser) Cleanse(schema types.Elements, data []byte) ([]byte, []Rewrite, error) {
+	root, err := utils.DecodeJSON(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+
+	items, ok := root.([]any)
+	if !ok {
+		return nil, nil, fmt.Errorf("payload must be a JSON array, got %s", jsonKind(root))
+	}
+
+	var (
+		rewrites = make([]Rewrite, 0)
+		values   = make([]any, 0, len(items))
+	)
+
+	for i, item := range items {
+		path := utils.JSONPointer("", i)
+		if i >= len(schema) {
+			rewrites = append(rewrites, Rewrite{Path: path, Kind: RewriteDrop, Before: item})
+			continue
+		}
+
+		value, err := c.cleanse(schema[i], item, path, &rewrites)
+		if err != nil {
+			return nil, nil, err
+		}
+		values = append(values, value)
+	}
+
+	result, err := json.Marshal(values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, rewrites, nil
+}
+
+// cleanse normalizes a single value according to its element
+// Dispatches to the appropriate type-specific cleanser based on the element type
+func (c *Cleanser) cleanse(elem types.Element, value any, path string, rewrites *[]Rewrite) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch elem.Type {
+	case types.String:
+		return c.cleanseString(value, path, rewrites)
+	case types.Int, types.Uint:
+		return c.cleanseNumber(value, path, rewrites)
+	case types.Bool:
+		return c.cleanseBool(value, path, rewrites)
+	case types.Address:
+		return c.cleanseAddress(value, path, rewrites)
+	case types.Bytes:
+		return c.cleanseBytes(value, path, rewrites)
+	case types.Array:
+		return c.cleanseArray(elem, value, path, rewrites)
+	case types.Object:
+		return c.cleanseObject(elem, value, path, rewrites)
+	}
+
+	return nil, fmt.Errorf("cleanser does not support %q at %q", elem.Type, path)
+}
+
+// cleanseString trims the whitespace surrounding a string
+func (c *Cleanser) cleanseString(value any, path string, rewrites *[]Rewrite) (any, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected string at %q, got %s", path, jsonKind(value))
+	}
+
+	return trim(s, path, rewrites), nil
+}
+
+// cleanseNumber coerces numeric strings, hex quantities and exponents into a decimal JSON number
+func (c *Cleanser) cleanseNumber(value any, path string, rewrites *[]Rewrite) (any, error) {
+	var raw string
+	switch v := value.(type) {
+	case json.Number:
+		raw = v.String()
+	case string:
+		raw = v
+	default:
+		return nil, fmt.Errorf("expected integer at %q, got %s", path, jsonKind(value))
+	}
+
+	number, err := parseInteger(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid integer at %q: %w", path, err)
+	}
+
+	result := json.Number(number.String())
+	if _, isNumber := value.(json.Number); !isNumber || raw != result.String() {
+		*rewrites = append(*rewrites, Rewrite{Path: path, Kind: RewriteNumber, Before: value, After: result})
+	}
+
+	return result, nil
+}
+
+// cleanseBool coerces "true"/"false" strings into booleans
+func (c *Cleanser) cleanseBool(value any, path string, rewrites *[]Rewrite) (any, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true":
+			*rewrites = append(*rewrites, Rewrite{Path: path, Kind: RewriteBool, Before: v, After: true})
+			return true, nil
+		case "false":
+			*rewrites = append(*rewrites, Rewrite{Path: path, Kind: RewriteBool, Before: v, After: false})
+			return false, nil
+		}
+	}
+
+	return nil, fmt.Errorf("expected boolean at %q, got %s", path, jsonKind(value))
+}
+
+// cleanseAddress trims an address and converts it to its EIP-55 checksum form
+func (c *Cleanser) cleanseAddress(value any, path string, rewrites *[]Rewrite) (any, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected address at %q, got %s", path, jsonKind(value))
+	}
+
+	s = trim(s, path, rewrites)
+	if !common.IsHexAddress(s) {
+		return nil, fmt.Errorf("invalid address at %q: %q", path, s)
+	}
+
+	checksummed := common.HexToAddress(s).Hex()
+	if checksummed != s {
+		*rewrites = append(*rewrites, Rewrite{Path: path, Kind: RewriteChecksum, Before: s, After: checksummed})
+	}
+
+	return checksummed, nil
+}
+
+// cleanseBytes normalizes hex strings and byte arrays into lower-case 0x-prefixed hex
+func (c *Cleanser) cleanseBytes(value any, path string, rewrites *[]Rewrite) (any, error) {
+	switch v := value.(type) {
+	case string:
+		s := trim(v, path, rewrites)
+
+		digits := s
+		if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+			digits = digits[2:]
+		}
+
+		if len(digits)%2 != 0 {
+			return nil, fmt.Errorf("invalid hex at %q: odd length", path)
+		}
+
+		if !isHex(digits) {
+			return nil, fmt.Errorf("invalid hex at %q: %q", path, s)
+		}
+
+		normalized := "0x" + strings.ToLower(digits)
+		if normalized != s {
+			*rewrites = append(*rewrites, Rewrite{Path: path, Kind: RewriteHex, Before: s, After: normalized})
+		}
+
+		return normalized, nil
+	case []any:
+		buf := make([]byte, len(v))
+		for i, item := range v {
+			n, ok := item.(json.Number)
+			if !ok {
+				return nil, fmt.Errorf("invalid byte at %q: %v", utils.JSONPointer(path, i), item)
+			}
+
+			b, ok := new(big.Int).SetString(n.String(), 10)
+			if !ok || b.Sign() < 0 || b.BitLen() > 8 {
+				return nil, fmt.Errorf("invalid byte at %q: %v", utils.JSONPointer(path, i), item)
+			}
+			buf[i] = byte(b.Uint64())
+		}
+
+		normalized := "0x" + common.Bytes2Hex(buf)
+		*rewrites = append(*rewrites, Rewrite{Path: path, Kind: RewriteHex, Before: v, After: normalized})
+		return normalized, nil
+	}
+
+	return nil, fmt.Errorf("expected bytes at %q, got %s", path, jsonKind(value))
+}
+
+// cleanseArray cleanses every item of an array with the array's child element
+func (c *Cleanser) cleanseArray(elem types.Element, value any, path string, rewrites *[]Rewrite) (any, error) {
+	if len(elem.Children) != 1 {
+		return nil, fmt.Errorf("array must have one child")
+	}
+
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected array at %q, got %s", path, jsonKind(value))
+	}
+
+	result := make([]any, len(items))
+	for i, item := range items {
+		cleansed, err := c.cleanse(elem.Children[0], item, utils.JSONPointer(path, i), rewrites)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = cleansed
+	}
+
+	return result, nil
+}
+
+// cleanseObject cleanses the known fields of an object in schema order
+// Fields that are not part of the schema are dropped and reported
+func (c *Cleanser) cleanseObject(elem types.Element, value any, path string, rewrites *[]Rewrite) (any, error) {
+	if len(elem.Children) == 0 {
+		return nil, fmt.Errorf("object must have at least one child")
+	}
+
+	fields, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected object at %q, got %s", path, jsonKind(value))
+	}
+
+	known := make(map[string]struct{}, len(elem.Children))
+	result := make(utils.OrderedObject, 0, len(elem.Children))
+	for _, child := range elem.Children {
+		known[child.Name] = struct{}{}
+
+		field, ok := fields[child.Name]
+		if !ok {
+			continue
+		}
+
+		cleansed, err := c.cleanse(child, field, utils.JSONPointer(path, child.Name), rewrites)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, utils.OrderedField{Key: child.Name, Value: cleansed})
+	}
+
+	unknown := make([]string, 0)
+	for key := range fields {
+		if _, ok := known[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		*rewrites = append(*rewrites, Rewrite{Path: utils.JSONPointer(path, key), Kind: RewriteDrop, Before: fields[key]})
+	}
+
+	return result, nil
+}
+
+// trim removes surrounding whitespace from a string and records the rewrite if anything changed
+func trim(s, path string, rewrites *[]Rewrite) string {
+	trimmed := strings.TrimSpace(s)
+	if trimmed != s {
+		*rewrites = append(*rewrites, Rewrite{Path: path, Kind: RewriteTrim, Before: s, After: trimmed})
+	}
+	return trimmed
+}
+
+// isHex reports whether s only contains hexadecimal digits
+func isHex(s string) bool {
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonKind returns the JSON kind name of a decoded value for error messages
+func jsonKind(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number, float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,98 @@
+package ether
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanser_Cleanse(t *testing.T) {
+	type Testcase struct {
+		Name     string
+		Schema   types.Elements
+		Input    string
+		Expected string
+		Rewrites []Rewrite
+	}
+
+	testcases := []Testcase{
+		{
+			Name:     "canonical-payload",
+			Schema:   types.Elements{{Type: types.String}, {Type: types.Uint, Size: 256}, {Type: types.Bool}},
+			Input:    `["hello", 1000, true]`,
+			Expected: `["hello",1000,true]`,
+			Rewrites: []Rewrite{},
+		},
+		{
+			Name:     "numbers",
+			Schema:   types.Elements{{Type: types.Uint, Size: 256}, {Type: types.Uint, Size: 256}, {Type: types.Int, Size: 128}, {Type: types.Uint}},
+			Input:    `[" 42 ", "0x2a", "-1e3", 1e18]`,
+			Expected: `[42,42,-1000,1000000000000000000]`,
+			Rewrites: []Rewrite{
+				{Path: "/0", Kind: RewriteNumber, Before: " 42 ", After: json.Number("42")},
+				{Path: "/1", Kind: RewriteNumber, Before: "0x2a", After: json.Number("42")},
+				{Path: "/2", Kind: RewriteNumber, Before: "-1e3", After: json.Number("-1000")},
+				{Path: "/3", Kind: RewriteNumber, Before: json.Number("1e18"), After: json.Number("1000000000000000000")},
+			},
+		},
+		{
+			Name:     "address,bytes,bytes32",
+			Schema:   types.Elements{{Type: types.Address}, {Type: types.Bytes}, {Type: types.Bytes, Size: 2}},
+			Input:    `[" 0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266", "DEADbeef", [1, 255]]`,
+			Expected: `["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266","0xdeadbeef","0x01ff"]`,
+			Rewrites: []Rewrite{
+				{Path: "/0", Kind: RewriteTrim, Before: " 0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266", After: "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"},
+				{Path: "/0", Kind: RewriteChecksum, Before: "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266", After: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+				{Path: "/1", Kind: RewriteHex, Before: "DEADbeef", After: "0xdeadbeef"},
+				{Path: "/2", Kind: RewriteHex, Before: []any{json.Number("1"), json.Number("255")}, After: "0x01ff"},
+			},
+		},
+		{
+			Name:     "(string name, (uint256 amount) balance)[]",
+			Schema:   types.Elements{{Type: types.Array, Children: types.Elements{{Type: types.Object, Children: types.Elements{{Name: "name", Type: types.String}, {Name: "balance", Type: types.Object, Children: types.Elements{{Name: "amount", Type: types.Uint, Size: 256}}}}}}}},
+			Input:    `[[{"extra": 1, "balance": {"amount": "10"}, "name": "welder "}]]`,
+			Expected: `[[{"name":"welder","balance":{"amount":10}}]]`,
+			Rewrites: []Rewrite{
+				{Path: "/0/0/name", Kind: RewriteTrim, Before: "welder ", After: "welder"},
+				{Path: "/0/0/balance/amount", Kind: RewriteNumber, Before: "10", After: json.Number("10")},
+				{Path: "/0/0/extra", Kind: RewriteDrop, Before: json.Number("1")},
+			},
+		},
+	}
+
+	cleanser := NewCleanser()
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, rewrites, err := cleanser.Cleanse(tc.Schema, []byte(tc.Input))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.Expected, string(actual))
+			assert.Equal(t, tc.Rewrites, rewrites)
+		})
+	}
+}
+
+func TestCleanser_CleanseInvalid(t *testing.T) {
+	type Testcase struct {
+		Name   string
+		Schema types.Elements
+		Input  string
+	}
+
+	testcases := []Testcase{
+		{Name: "not-an-array", Schema: types.Elements{{Type: types.String}}, Input: `{"a": 1}`},
+		{Name: "fractional-number", Schema: types.Elements{{Type: types.Uint}}, Input: `[1.5]`},
+		{Name: "invalid-address", Schema: types.Elements{{Type: types.Address}}, Input: `["0x1234"]`},
+		{Name: "odd-hex", Schema: types.Elements{{Type: types.Bytes}}, Input: `["0xabc"]`},
+		{Name: "float", Schema: types.Elements{{Type: types.Float}}, Input: `[1.5]`},
+	}
+
+	cleanser := NewCleanser()
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, _, err := cleanser.Cleanse(tc.Schema, []byte(tc.Input))
+			assert.Error(t, err)
+		})
+	}
+}
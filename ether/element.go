@@ -15,8 +15,20 @@ import (
 type AbiElements []abi.Argument
 
 // Encode packs the provided values according to the ABI specification
-// Returns the packed bytes or an error if packing fails
-func (a AbiElements) Encode(values ...any) ([]byte, error) { return abi.Arguments(a).Pack(values...) }
+// Nil pointers left by optional elements are encoded as the zero value of their type,
+// the values are copied for that and are never modified
+// Returns the packed bytes or an error if packing fails, e.g. for a nil pointer in a struct with unexported fields
+func (a AbiElements) Encode(values ...any) ([]byte, error) {
+	packed := make([]any, len(values))
+	for i, value := range values {
+		var err error
+		if packed[i], err = packable(value); err != nil {
+			return nil, err
+		}
+	}
+
+	return abi.Arguments(a).Pack(packed...)
+}
 
 // EncodeWithFunctionSignature encodes values with an optional Ethereum ABI function signature prepended to the data.
 // If the function signature is empty, only the encoded values are returned.
@@ -36,18 +48,86 @@ func (a AbiElements) EncodeWithFunctionSignature(name string, values ...any) ([]
 	return append(signature, data...), nil
 }
 
+// EncodeCall encodes values as calldata for the named function
+// The canonical signature and its 4-byte selector are derived from the ABI types themselves,
+// so the selector always matches the packed arguments
+// Returns the encoded byte array or an error if encoding fails
+func (a AbiElements) EncodeCall(functionName string, values ...any) ([]byte, error) {
+	selector, err := a.Selector(functionName)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := a.Encode(values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode values: %w", err)
+	}
+
+	return append(selector, data...), nil
+}
+
+// Signature returns the canonical signature of the named function taking these arguments
+// Tuples are expanded and integer types are rendered with their explicit size, e.g. `f(uint256,(address,bool))`
+func (a AbiElements) Signature(functionName string) (string, error) {
+	elements, err := NewEtherParser().Deserialize(a)
+	if err != nil {
+		return "", err
+	}
+
+	return FormatSignature(functionName, elements)
+}
+
+// Selector returns the 4-byte selector of the named function taking these arguments
+func (a AbiElements) Selector(functionName string) ([]byte, error) {
+	signature, err := a.Signature(functionName)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256([]byte(signature))[:4], nil
+}
+
 // Decode unpacks the provided data according to the ABI specification
 // Returns the unpacked values or an error if unpacking fails
 func (a AbiElements) Decode(data []byte) ([]any, error) {
 	return abi.Arguments(a).Unpack(data)
 }
 
+// OptionalPolicy defines how the EtherParser serializes optional elements
+// The EVM has no notion of a missing value, so optional elements must be either rejected or zeroed
+type OptionalPolicy int
+
+const (
+	// RejectOptional makes serialization fail for schemas containing optional elements
+	RejectOptional OptionalPolicy = iota
+	// ZeroOptional serializes optional elements as their regular type
+	// Missing values are encoded as the zero value of the type
+	ZeroOptional
+)
+
+// ParserOption contains configuration options for the EtherParser
+type ParserOption struct {
+	// OptionalPolicy defines how optional elements are serialized, RejectOptional by default
+	OptionalPolicy OptionalPolicy
+}
+
 // EtherParser is responsible for converting between types.Elements and AbiElements
 // It implements the types.Parser interface
-type EtherParser[T AbiElements] struct{}
+type EtherParser[T AbiElements] struct {
+	// optionalPolicy defines how optional elements are serialized
+	optionalPolicy OptionalPolicy
+}
 
 // NewEtherParser creates a new instance of EtherParser
-func NewEtherParser[T AbiElements]() *EtherParser[T] { return &EtherParser[T]{} }
+// If no options are provided, optional elements are rejected
+func NewEtherParser[T AbiElements](opts ...ParserOption) *EtherParser[T] {
+	var opt ParserOption
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	return &EtherParser[T]{optionalPolicy: opt.OptionalPolicy}
+}
 
 // Serialize converts types.Elements to AbiElements (T)
 // Returns the serialized elements or an error if serialization fails
@@ -70,6 +150,7 @@ func (e *EtherParser[T]) serialize(elements types.Elements) (T, error) {
 		}
 
 		args[i].Name = elem.Name
+		args[i].Indexed = elem.Indexed
 	}
 
 	return args, nil
@@ -83,6 +164,10 @@ func (e *EtherParser[T]) encode(elem types.Element) (abi.Type, error) {
 		err error
 	)
 
+	if elem.Optional && e.optionalPolicy != ZeroOptional {
+		return emptyTy, fmt.Errorf("parser does not support optional element %q", elem.Name)
+	}
+
 	switch elem.Type {
 	case types.String:
 		ty, err = e.encodeString(elem)
@@ -248,6 +333,7 @@ func (e *EtherParser[T]) deserialize(args T) (types.Elements, error) {
 		}
 
 		elem.Name = arg.Name
+		elem.Indexed = arg.Indexed
 		elements[i] = *elem
 	}
 
@@ -1,6 +1,7 @@
 package ether
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -9,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ideatru/welder/internal/builder"
 	"github.com/ideatru/welder/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -303,3 +305,159 @@ func TestEtherParser_Deserialize(t *testing.T) {
 		})
 	}
 }
+
+func TestAbiElements_EncodeCall(t *testing.T) {
+	type Testcase struct {
+		Name      string
+		Input     types.Elements
+		Signature string
+		Selector  []byte
+	}
+
+	testcases := []Testcase{
+		{
+			Name:      "transfer",
+			Input:     types.Elements{{Name: "to", Type: types.Address}, {Name: "amount", Type: types.Uint, Size: 256}},
+			Signature: "transfer(address,uint256)",
+			Selector:  hexutil.MustDecode("0xa9059cbb"),
+		},
+		{
+			Name:      "getData",
+			Input:     types.Elements{{Type: types.String}, {Type: types.Object, Children: types.Elements{{Name: "owner", Type: types.Address}, {Name: "name", Type: types.String}, {Name: "balance", Type: types.Object, Children: types.Elements{{Name: "amount", Type: types.Uint, Size: 256}, {Name: "currency", Type: types.String}}}}}},
+			Signature: "getData(string,(address,string,(uint256,string)))",
+		},
+		{
+			Name:      "setValues",
+			Input:     types.Elements{{Type: types.Array, Size: 2, Children: types.Elements{{Type: types.Int}}}, {Type: types.Bytes}},
+			Signature: "setValues(int64[2],bytes)",
+		},
+	}
+
+	parser := NewEtherParser()
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			args, err := parser.Serialize(tc.Input)
+			assert.NoError(t, err)
+
+			signature, err := args.Signature(tc.Name)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Signature, signature)
+
+			if tc.Selector != nil {
+				selector, err := args.Selector(tc.Name)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Selector, selector)
+			}
+		})
+	}
+
+	args, err := parser.Serialize(testcases[0].Input)
+	assert.NoError(t, err)
+
+	values := []any{common.HexToAddress("0xB035aD4B31759d909178d32da02266BD199c7e15"), big.NewInt(1)}
+	expected, err := args.EncodeWithFunctionSignature("transfer(address,uint256)", values...)
+	assert.NoError(t, err)
+
+	actual, err := args.EncodeCall("transfer", values...)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	_, err = args.EncodeCall("transfer(address,uint256)", values...)
+	assert.Error(t, err)
+}
+
+func TestAbiElements_EncodeHexBytes(t *testing.T) {
+	schema := types.Elements{{Type: types.Object, Children: types.Elements{
+		{Name: "payload", Type: types.Bytes},
+		{Name: "tags", Type: types.Array, Children: types.Elements{{Type: types.Bytes}}},
+	}}}
+
+	args, err := NewEtherParser().Serialize(schema)
+	assert.NoError(t, err)
+
+	values, err := builder.New(EtherBuilderOptions).Builds(schema)
+	assert.NoError(t, err)
+
+	payload := `{"payload":"0xcafe","tags":["0x01","0x"]}`
+	assert.NoError(t, json.Unmarshal([]byte(payload), values[0]))
+
+	// Built dynamic bytes are hexutil.Bytes so that built values marshal as hex
+	marshalled, err := json.Marshal(values[0])
+	assert.NoError(t, err)
+	assert.JSONEq(t, payload, string(marshalled))
+
+	data, err := args.Encode(values...)
+	assert.NoError(t, err)
+
+	expected, err := args.Encode(struct {
+		Payload []byte
+		Tags    [][]byte
+	}{[]byte{0xca, 0xfe}, [][]byte{{0x01}, {}}})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, data)
+
+	marshalled, err = json.Marshal(values[0])
+	assert.NoError(t, err)
+	assert.JSONEq(t, payload, string(marshalled))
+}
+
+func TestAbiElements_EncodeNilPointers(t *testing.T) {
+	schema := types.Elements{{Type: types.Object, Children: types.Elements{
+		{Name: "deadline", Type: types.Uint, Size: 256, Optional: true},
+		{Name: "memo", Type: types.String, Optional: true},
+	}}}
+
+	args, err := NewEtherParser(ParserOption{OptionalPolicy: ZeroOptional}).Serialize(schema)
+	assert.NoError(t, err)
+
+	type Order struct {
+		Deadline **big.Int
+		Memo     *string
+	}
+
+	order := &Order{}
+	data, err := args.Encode(order)
+	assert.NoError(t, err)
+
+	expected, err := args.Encode(struct {
+		Deadline *big.Int
+		Memo     string
+	}{big.NewInt(0), ""})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, data)
+
+	// Nil pointers are encoded as zero values without being replaced in the caller's value
+	assert.Nil(t, order.Deadline)
+	assert.Nil(t, order.Memo)
+}
+
+func TestAbiElements_EncodeUnexportedFields(t *testing.T) {
+	schema := types.Elements{{Type: types.Object, Children: types.Elements{
+		{Name: "amount", Type: types.Uint, Size: 256},
+		{Name: "memo", Type: types.String, Optional: true},
+	}}}
+
+	args, err := NewEtherParser(ParserOption{OptionalPolicy: ZeroOptional}).Serialize(schema)
+	assert.NoError(t, err)
+
+	type Order struct {
+		Amount *big.Int
+		Memo   *string
+		note   string
+	}
+
+	memo := "memo"
+	expected, err := args.Encode(struct {
+		Amount *big.Int
+		Memo   string
+	}{big.NewInt(1), memo})
+	assert.NoError(t, err)
+
+	data, err := args.Encode(Order{Amount: big.NewInt(1), Memo: &memo})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, data)
+
+	// Structs with unexported fields cannot be copied, their nil pointers are reported instead of panicking
+	_, err = args.Encode(&Order{Amount: big.NewInt(1)})
+	assert.ErrorContains(t, err, "has unexported fields")
+}
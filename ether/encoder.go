@@ -0,0 +1,203 @@
+package ether
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+// Encoder packs Go values into ABI encoded data directly from the schema,
+// without the strict type matching of abi.Arguments.Pack
+// Values are loosely typed: objects are map[string]any, arrays are []any or any slice,
+// integers are json.Number, decimal strings, big.Int or any Go integer, and bytes are hex strings or []byte
+// Payloads follow the rules of Weld: missing values take their default, missing or nil optional values
+// are encoded as zero values and object keys unknown to the schema are ignored
+type Encoder struct{}
+
+// NewEncoder creates a new instance of Encoder
+func NewEncoder() *Encoder { return &Encoder{} }
+
+// EncodeValues packs the values according to the schema
+func EncodeValues(schema types.Elements, values ...any) ([]byte, error) {
+	return NewEncoder().Encode(schema, values...)
+}
+
+// Encode packs the values according to the schema
+// Returns the ABI encoded data or a ValidationError for the first invalid value
+func (e *Encoder) Encode(schema types.Elements, values ...any) ([]byte, error) {
+	if len(values) > len(schema) {
+		return nil, ValidationError{Path: utils.JSONPointer("", len(schema)), Code: CodeUnknownField, Value: values[len(schema)], Message: fmt.Sprintf("schema only declares %d values", len(schema))}
+	}
+
+	items := make([]packed, len(schema))
+	for i, elem := range schema {
+		var value any
+		if i < len(values) {
+			value = values[i]
+		}
+
+		var err error
+		if items[i], err = e.encode(elem, value, utils.JSONPointer("", i)); err != nil {
+			return nil, err
+		}
+	}
+
+	return packTuple(items).data, nil
+}
+
+// encode packs a single value according to its element
+// Dispatches to the appropriate type-specific encoder based on the element type
+func (e *Encoder) encode(elem types.Element, value any, path string) (packed, error) {
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer && v.Type() != bigIntTy {
+		if v.IsNil() {
+			value = nil
+		} else {
+			value = v.Elem().Interface()
+		}
+	}
+
+	if value == nil {
+		return e.encodeMissing(elem, path)
+	}
+
+	switch elem.Type {
+	case types.Int, types.Uint:
+		return e.encodeInteger(elem, value, path)
+	case types.Address:
+		if address, ok := value.(common.Address); ok {
+			return packed{data: word(address.Bytes())}, nil
+		}
+	case types.Bytes:
+		return e.encodeBytes(elem, value, path)
+	case types.Array:
+		return e.encodeArray(elem, value, path)
+	case types.Object:
+		return e.encodeObject(elem, value, path)
+	}
+
+	return packScalar(elem, value, path)
+}
+
+// encodeMissing packs an absent or nil value with the element default,
+// or with its zero value if it is optional
+func (e *Encoder) encodeMissing(elem types.Element, path string) (packed, error) {
+	if elem.Default != nil {
+		def, err := utils.DecodeJSON(elem.Default)
+		if err != nil {
+			return packed{}, failure(elem, path, CodeInvalidSchema, nil, "invalid default: %v", err)
+		}
+
+		elem.Default = nil
+		return e.encode(elem, def, path)
+	}
+
+	if elem.Optional {
+		return packZero(elem), nil
+	}
+
+	return packed{}, missing(elem, path)
+}
+
+// encodeInteger packs Go integers and big.Int values, JSON forms are left to packScalar
+// Floats are rejected like when welding, JSON numbers must be decoded as json.Number to keep every digit
+func (e *Encoder) encodeInteger(elem types.Element, value any, path string) (packed, error) {
+	switch val := value.(type) {
+	case *big.Int:
+		return packInteger(elem, val, path, value)
+	case big.Int:
+		return packInteger(elem, &val, path, value)
+	}
+
+	v := reflect.ValueOf(value)
+	switch {
+	case v.CanInt():
+		return packInteger(elem, big.NewInt(v.Int()), path, value)
+	case v.CanUint():
+		return packInteger(elem, new(big.Int).SetUint64(v.Uint()), path, value)
+	}
+
+	return packScalar(elem, value, path)
+}
+
+// encodeBytes packs byte slices and byte arrays, JSON forms are left to packScalar
+func (e *Encoder) encodeBytes(elem types.Element, value any, path string) (packed, error) {
+	if data, ok := value.([]byte); ok {
+		return packBytes(elem, data, path, value)
+	}
+
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 {
+		data := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(data), v)
+		return packBytes(elem, data, path, value)
+	}
+
+	return packScalar(elem, value, path)
+}
+
+// encodeArray packs the items of a slice or an array
+// Missing items of fixed-size arrays are filled with the child default
+func (e *Encoder) encodeArray(elem types.Element, value any, path string) (packed, error) {
+	if len(elem.Children) != 1 {
+		return packed{}, failure(elem, path, CodeInvalidSchema, nil, "array must have one child")
+	}
+
+	values, ok := value.([]any)
+	if !ok {
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return packed{}, mismatch(elem, path, value)
+		}
+
+		values = make([]any, v.Len())
+		for i := range values {
+			values[i] = v.Index(i).Interface()
+		}
+	}
+
+	child := elem.Children[0]
+	if elem.Size > 0 && len(values) != elem.Size && (len(values) > elem.Size || child.Default == nil) {
+		return packed{}, failure(elem, path, CodeInvalidLength, nil, "expected %d items, got %d", elem.Size, len(values))
+	}
+
+	items := make([]packed, max(len(values), elem.Size))
+	for i := range items {
+		var item any
+		if i < len(values) {
+			item = values[i]
+		}
+
+		var err error
+		if items[i], err = e.encode(child, item, utils.JSONPointer(path, i)); err != nil {
+			return packed{}, err
+		}
+	}
+
+	return packArray(elem, items), nil
+}
+
+// encodeObject packs the fields of a map in the order of the schema
+// Keys unknown to the schema are ignored, the same as when welding
+func (e *Encoder) encodeObject(elem types.Element, value any, path string) (packed, error) {
+	if len(elem.Children) == 0 {
+		return packed{}, failure(elem, path, CodeInvalidSchema, nil, "object must have at least one child")
+	}
+
+	fields, ok := value.(map[string]any)
+	if !ok {
+		return packed{}, mismatch(elem, path, value)
+	}
+
+	items := make([]packed, len(elem.Children))
+	for i, child := range elem.Children {
+		var err error
+		if items[i], err = e.encode(child, fields[child.Name], utils.JSONPointer(path, child.Name)); err != nil {
+			return packed{}, err
+		}
+	}
+
+	return packTuple(items), nil
+}
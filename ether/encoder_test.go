@@ -0,0 +1,188 @@
+package ether
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoder_RoundTrip(t *testing.T) {
+	type Testcase struct {
+		Name   string
+		Schema types.Elements
+	}
+
+	testcases := []Testcase{
+		{
+			Name:   "primitives",
+			Schema: types.Elements{{Type: types.String}, {Type: types.Bool}, {Type: types.Address}, {Type: types.Bytes}, {Type: types.Bytes, Size: 32}},
+		},
+		{
+			Name:   "integers",
+			Schema: types.Elements{{Type: types.Uint, Size: 8}, {Type: types.Int, Size: 8}, {Type: types.Uint}, {Type: types.Int, Size: 24}, {Type: types.Uint, Size: 256}, {Type: types.Int, Size: 256}},
+		},
+		{
+			Name: "nested",
+			Schema: types.Elements{
+				{Type: types.Array, Size: 3, Children: types.Elements{{Type: types.Array, Children: types.Elements{{Type: types.Uint, Size: 128}}}}},
+				{Type: types.Object, Children: types.Elements{
+					{Name: "owner", Type: types.Address},
+					{Name: "tags", Type: types.Array, Children: types.Elements{{Type: types.Bytes, Size: 4}}},
+					{Name: "balance", Type: types.Object, Children: types.Elements{{Name: "amount", Type: types.Int, Size: 64}, {Name: "currency", Type: types.String}}},
+				}},
+			},
+		},
+		{
+			Name: "static-tuples",
+			Schema: types.Elements{
+				{Type: types.Array, Size: 2, Children: types.Elements{{Type: types.Object, Children: types.Elements{{Name: "id", Type: types.Uint, Size: 32}, {Name: "flag", Type: types.Bool}}}}},
+				{Type: types.Array, Size: 2, Children: types.Elements{{Type: types.String}}},
+			},
+		},
+	}
+
+	parser := NewEtherParser(ParserOption{OptionalPolicy: ZeroOptional})
+	unwelder := NewUnwelder(StringIntegers)
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			args, err := parser.Serialize(tc.Schema)
+			assert.NoError(t, err)
+
+			generator := NewGenerator(GeneratorOption{Seed: 7})
+			for i := 0; i < 50; i++ {
+				values, err := generator.Generate(tc.Schema)
+				assert.NoError(t, err)
+
+				expected, err := args.Encode(values...)
+				assert.NoError(t, err)
+
+				payload, err := unwelder.Marshal(tc.Schema, values)
+				assert.NoError(t, err)
+
+				loose, err := utils.DecodeJSON(payload)
+				assert.NoError(t, err)
+
+				actual, err := EncodeValues(tc.Schema, loose.([]any)...)
+				assert.NoError(t, err)
+				assert.Equal(t, hex.EncodeToString(expected), hex.EncodeToString(actual), string(payload))
+			}
+		})
+	}
+}
+
+func TestEncoder_Encode(t *testing.T) {
+	schema := types.Elements{
+		{Type: types.Int, Size: 128},
+		{Type: types.Object, Children: types.Elements{
+			{Name: "owner", Type: types.Address},
+			{Name: "limit", Type: types.Uint, Size: 16, Default: json.RawMessage(`7`)},
+			{Name: "memo", Type: types.Bytes, Optional: true},
+		}},
+		{Type: types.Array, Size: 3, Children: types.Elements{{Type: types.Bytes, Size: 2, Default: json.RawMessage(`"0xffff"`)}}},
+		{Type: types.Array, Children: types.Elements{{Type: types.Uint, Size: 32}}},
+	}
+
+	parser := NewEtherParser(ParserOption{OptionalPolicy: ZeroOptional})
+	args, err := parser.Serialize(schema)
+	assert.NoError(t, err)
+
+	type Account struct {
+		Owner common.Address
+		Limit uint16
+		Memo  []byte
+	}
+
+	owner := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	amount, _ := new(big.Int).SetString("-170141183460469231731687303715884105728", 10)
+
+	type Testcase struct {
+		Name     string
+		Input    []any
+		Expected []any
+		Error    string
+	}
+
+	testcases := []Testcase{
+		{
+			Name: "loose-values",
+			Input: []any{
+				"-170141183460469231731687303715884105728",
+				map[string]any{"owner": owner.Hex(), "limit": json.Number("9"), "memo": "0x0102"},
+				[]any{"0x0001", []any{json.Number("0"), json.Number("2")}, "0x0003"},
+				[]any{json.Number("1"), json.Number("2"), "3"},
+			},
+			Expected: []any{amount, Account{owner, 9, []byte{1, 2}}, [3][2]byte{{0, 1}, {0, 2}, {0, 3}}, []uint32{1, 2, 3}},
+		},
+		{
+			Name: "go-values",
+			Input: []any{
+				amount,
+				map[string]any{"owner": owner, "limit": uint16(9), "memo": []byte{1, 2}},
+				[][2]byte{{0, 1}},
+				[]int{1, 2, 3},
+			},
+			Expected: []any{amount, Account{owner, 9, []byte{1, 2}}, [3][2]byte{{0, 1}, {0xff, 0xff}, {0xff, 0xff}}, []uint32{1, 2, 3}},
+		},
+		{
+			Name:     "defaults-and-zero-values",
+			Input:    []any{int64(-1), map[string]any{"owner": &owner, "memo": nil}, []any{}, []uint32{}},
+			Expected: []any{big.NewInt(-1), Account{owner, 7, []byte{}}, [3][2]byte{{0xff, 0xff}, {0xff, 0xff}, {0xff, 0xff}}, []uint32{}},
+		},
+		{
+			Name:  "required",
+			Input: []any{1, map[string]any{}, []any{}, []any{}},
+			Error: "/1/owner: value is required",
+		},
+		{
+			Name:  "out-of-range",
+			Input: []any{1, map[string]any{"owner": owner}, []any{}, []uint64{1 << 32}},
+			Error: "/3/0: value must be between 0 and 4294967295",
+		},
+		{
+			Name:     "unknown-fields",
+			Input:    []any{1, map[string]any{"owner": owner, "extra": 1, "nested": map[string]any{"a": 1}}, []any{}, []any{}},
+			Expected: []any{big.NewInt(1), Account{owner, 7, []byte{}}, [3][2]byte{{0xff, 0xff}, {0xff, 0xff}, {0xff, 0xff}}, []uint32{}},
+		},
+		{
+			Name:  "float",
+			Input: []any{float64(1)},
+			Error: "/0: expected int, got number",
+		},
+		{
+			Name:  "too-many-items",
+			Input: []any{1, map[string]any{"owner": owner}, []any{"0x0001", "0x0002", "0x0003", "0x0004"}, []any{}},
+			Error: "/2: expected 3 items, got 4",
+		},
+		{
+			Name:  "too-many-values",
+			Input: []any{1, map[string]any{"owner": owner}, []any{}, []any{}, 5},
+			Error: "/4: schema only declares 4 values",
+		},
+		{
+			Name:  "type-mismatch",
+			Input: []any{1, []any{owner}},
+			Error: "/1: expected object, got array",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := EncodeValues(schema, tc.Input...)
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+				return
+			}
+			assert.NoError(t, err)
+
+			expected, err := args.Encode(tc.Expected...)
+			assert.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(expected), hex.EncodeToString(actual))
+		})
+	}
+}
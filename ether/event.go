@@ -0,0 +1,130 @@
+package ether
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ideatru/welder/types"
+)
+
+// Event describes a Solidity event by its name and inputs
+// Inputs marked Indexed are emitted as topics, the others are ABI encoded in the log data
+type Event struct {
+	// Name is the event name, e.g. `Transfer`
+	Name string
+	// Anonymous reports whether the event omits its signature hash from the topics
+	Anonymous bool
+	// Inputs is the schema of the event arguments
+	Inputs types.Elements
+}
+
+// Signature returns the canonical signature of the event, e.g. `Transfer(address,address,uint256)`
+func (e Event) Signature() (string, error) {
+	return FormatSignature(e.Name, e.Inputs)
+}
+
+// ID returns the keccak256 hash of the event signature, emitted as topic0 unless the event is anonymous
+func (e Event) ID() (common.Hash, error) {
+	signature, err := e.Signature()
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return crypto.Keccak256Hash([]byte(signature)), nil
+}
+
+// Decode decodes the topics and data of a log emitted by the event
+// Values are returned in the order of the inputs; indexed inputs of reference types
+// (strings, dynamic bytes, arrays and tuples) are only available as their keccak256 hash
+// and are returned as common.Hash
+// Returns an error if topic0 does not match the event ID or the topic count is wrong
+func (e Event) Decode(topics [][32]byte, data []byte) ([]any, error) {
+	if !e.Anonymous {
+		id, err := e.ID()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(topics) == 0 || common.Hash(topics[0]) != id {
+			return nil, fmt.Errorf("log is not a %q event: topic0 does not match %s", e.Name, id.Hex())
+		}
+		topics = topics[1:]
+	}
+
+	indexed := 0
+	for _, input := range e.Inputs {
+		if input.Indexed {
+			indexed++
+		}
+	}
+
+	if indexed != len(topics) {
+		return nil, fmt.Errorf("event %q expects %d indexed topics, got %d", e.Name, indexed, len(topics))
+	}
+
+	parser := NewEtherParser()
+	args, err := parser.Serialize(e.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	// abi.Arguments.Unpack skips indexed arguments, leaving only the values of the log data
+	unindexed, err := args.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %q data: %w", e.Name, err)
+	}
+
+	values := make([]any, len(e.Inputs))
+	for i, input := range e.Inputs {
+		if !input.Indexed {
+			values[i], unindexed = unindexed[0], unindexed[1:]
+			continue
+		}
+
+		topic := topics[0]
+		topics = topics[1:]
+
+		if isHashedTopic(input) {
+			values[i] = common.Hash(topic)
+			continue
+		}
+
+		arg := args[i]
+		arg.Indexed = false
+
+		value, err := abi.Arguments{arg}.Unpack(topic[:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %q topic %q: %w", e.Name, input.Name, err)
+		}
+		values[i] = value[0]
+	}
+
+	return values, nil
+}
+
+// decodedInputs returns the schema of the values returned by Decode
+// Indexed inputs of reference types are replaced with the bytes32 hash they are emitted as
+func (e Event) decodedInputs() types.Elements {
+	inputs := make(types.Elements, len(e.Inputs))
+	for i, input := range e.Inputs {
+		if input.Indexed && isHashedTopic(input) {
+			input = types.Element{Name: input.Name, Type: types.Bytes, Size: 32, Indexed: true}
+		}
+		inputs[i] = input
+	}
+	return inputs
+}
+
+// isHashedTopic reports whether an indexed input is emitted as the keccak256 hash of its value
+func isHashedTopic(elem types.Element) bool {
+	switch elem.Type {
+	case types.String, types.Array, types.Object:
+		return true
+	case types.Bytes:
+		return elem.Size <= 0
+	}
+
+	return false
+}
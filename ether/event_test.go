@@ -0,0 +1,98 @@
+package ether
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_Decode(t *testing.T) {
+	type Testcase struct {
+		Name     string
+		Event    Event
+		Topics   [][32]byte
+		Data     []any
+		Expected string
+	}
+
+	from := common.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	to := common.HexToAddress("0xb035ad4b31759d909178d32da02266bd199c7e15")
+	transfer := Event{Name: "Transfer", Inputs: types.Elements{
+		{Name: "from", Type: types.Address, Indexed: true},
+		{Name: "to", Type: types.Address, Indexed: true},
+		{Name: "value", Type: types.Uint, Size: 256},
+	}}
+	named := Event{Name: "Named", Inputs: types.Elements{
+		{Name: "name", Type: types.String, Indexed: true},
+		{Name: "id", Type: types.Int, Size: 8, Indexed: true},
+		{Type: types.String},
+	}}
+
+	testcases := []Testcase{
+		{
+			Name:     "transfer",
+			Event:    transfer,
+			Topics:   [][32]byte{crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")), common.BytesToHash(from[:]), common.BytesToHash(to[:])},
+			Data:     []any{big.NewInt(1000)},
+			Expected: `{"from":"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266","to":"0xB035aD4B31759d909178d32da02266BD199c7e15","value":"1000"}`,
+		},
+		{
+			Name:     "hashed-and-signed-topics",
+			Event:    named,
+			Topics:   [][32]byte{crypto.Keccak256Hash([]byte("Named(string,int8,string)")), crypto.Keccak256Hash([]byte("alice")), common.BigToHash(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(2)))},
+			Data:     []any{"hello"},
+			Expected: `{"name":"` + crypto.Keccak256Hash([]byte("alice")).Hex() + `","id":-2,"arg2":"hello"}`,
+		},
+		{
+			Name:     "anonymous",
+			Event:    Event{Name: "Ping", Anonymous: true, Inputs: types.Elements{{Name: "flag", Type: types.Bool, Indexed: true}}},
+			Topics:   [][32]byte{common.BigToHash(big.NewInt(1))},
+			Expected: `{"flag":true}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			data := encodeEventData(t, tc.Event, tc.Data)
+
+			actual, err := NewUnwelder(SafeIntegers).UnweldEvent(tc.Event, tc.Topics, data)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, string(actual))
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		data := encodeEventData(t, transfer, []any{big.NewInt(1)})
+		id := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+		_, err := transfer.Decode([][32]byte{crypto.Keccak256Hash([]byte("Approval(address,address,uint256)")), {}, {}}, data)
+		assert.ErrorContains(t, err, "topic0")
+
+		_, err = transfer.Decode([][32]byte{id, {}}, data)
+		assert.ErrorContains(t, err, "expects 2 indexed topics")
+
+		_, err = transfer.Decode(nil, data)
+		assert.Error(t, err)
+	})
+}
+
+// encodeEventData encodes the values of the unindexed inputs of the event
+func encodeEventData(t *testing.T, event Event, values []any) []byte {
+	unindexed := make(types.Elements, 0, len(event.Inputs))
+	for _, input := range event.Inputs {
+		if !input.Indexed {
+			unindexed = append(unindexed, input)
+		}
+	}
+
+	args, err := NewEtherParser().Serialize(unindexed)
+	assert.NoError(t, err)
+
+	data, err := args.Encode(values...)
+	assert.NoError(t, err)
+	return data
+}
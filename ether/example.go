@@ -0,0 +1,98 @@
+package ether
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ideatru/welder/internal/builder"
+	"github.com/ideatru/welder/types"
+)
+
+// exampleString is the value of every string in example payloads
+const exampleString = "example"
+
+// ExampleJSON renders a deterministic, human-readable example payload for the schema
+// Strings are `"example"`, integers are 1, booleans are true, addresses and bytes end with 0x01,
+// dynamic arrays hold a single item, fixed-size arrays are filled, and every object field
+// and optional element is populated, so the payload is accepted by welding
+// Returns an error if the schema cannot be built
+func ExampleJSON(schema types.Elements) ([]byte, error) {
+	values, err := builder.New(EtherBuilderOptions).Builds(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, elem := range schema {
+		if err := example(elem, reflect.ValueOf(values[i]).Elem()); err != nil {
+			return nil, err
+		}
+	}
+
+	// Integers are rendered as plain JSON numbers, the only form welding accepts
+	return NewUnwelder(NumberIntegers).Marshal(schema, values)
+}
+
+// example fills the target built for the element with its example value
+func example(elem types.Element, target reflect.Value) error {
+	if target.Kind() == reflect.Pointer && target.Type() != bigIntTy {
+		target.Set(reflect.New(target.Type().Elem()))
+		target = target.Elem()
+	}
+
+	switch elem.Type {
+	case types.String:
+		target.SetString(exampleString)
+	case types.Bool:
+		target.SetBool(true)
+	case types.Address:
+		target.Set(reflect.ValueOf(common.BytesToAddress([]byte{1})))
+	case types.Int, types.Uint:
+		switch {
+		case target.Type() == bigIntTy:
+			target.Set(reflect.ValueOf(big.NewInt(1)))
+		case target.CanInt():
+			target.SetInt(1)
+		case target.CanUint():
+			target.SetUint(1)
+		default:
+			return fmt.Errorf("cannot build example of %q into %s", elem.Type, target.Type())
+		}
+	case types.Bytes:
+		switch target.Kind() {
+		case reflect.Array:
+			target.Index(target.Len() - 1).SetUint(1)
+		case reflect.Slice:
+			target.SetBytes([]byte{1})
+		default:
+			return fmt.Errorf("cannot build example of %q into %s", elem.Type, target.Type())
+		}
+	case types.Array:
+		if len(elem.Children) != 1 || target.Kind() != reflect.Slice {
+			return fmt.Errorf("cannot build example of %q into %s", elem.Type, target.Type())
+		}
+
+		length := max(elem.Size, 1)
+		target.Set(reflect.MakeSlice(target.Type(), length, length))
+		for i := 0; i < length; i++ {
+			if err := example(elem.Children[0], target.Index(i)); err != nil {
+				return err
+			}
+		}
+	case types.Object:
+		if target.Kind() != reflect.Struct || target.NumField() != len(elem.Children) {
+			return fmt.Errorf("cannot build example of %q into %s", elem.Type, target.Type())
+		}
+
+		for i, child := range elem.Children {
+			if err := example(child, target.Field(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("example does not support %q", elem.Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package ether
+
+import (
+	"testing"
+
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExampleJSON(t *testing.T) {
+	type Testcase struct {
+		Name     string
+		Schema   types.Elements
+		Expected string
+	}
+
+	testcases := []Testcase{
+		{
+			Name:     "primitives",
+			Schema:   types.Elements{{Type: types.String}, {Type: types.Bool}, {Type: types.Address}, {Type: types.Uint, Size: 256}, {Type: types.Int, Size: 8}, {Type: types.Bytes}, {Type: types.Bytes, Size: 4}},
+			Expected: `["example",true,"0x0000000000000000000000000000000000000001",1,1,"0x01","0x00000001"]`,
+		},
+		{
+			Name: "arrays",
+			Schema: types.Elements{
+				{Type: types.Array, Children: types.Elements{{Type: types.Uint}}},
+				{Type: types.Array, Size: 2, Children: types.Elements{{Type: types.Array, Children: types.Elements{{Type: types.String}}}}},
+			},
+			Expected: `[[1],[["example"],["example"]]]`,
+		},
+		{
+			Name: "nested-objects",
+			Schema: types.Elements{{Type: types.Object, Children: types.Elements{
+				{Name: "owner", Type: types.Address},
+				{Name: "memo", Type: types.String, Optional: true},
+				{Name: "balance", Type: types.Object, Children: types.Elements{{Name: "amount", Type: types.Uint, Size: 256}, {Name: "currency", Type: types.String}}},
+			}}},
+			Expected: `[{"owner":"0x0000000000000000000000000000000000000001","memo":"example","balance":{"amount":1,"currency":"example"}}]`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := ExampleJSON(tc.Schema)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, string(actual))
+			assert.Empty(t, Validate(tc.Schema, actual))
+		})
+	}
+
+	_, err := ExampleJSON(types.Elements{{Type: types.Float}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,247 @@
+package ether
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ideatru/welder/internal/builder"
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+const (
+	// defaultMaxItems is the default upper bound of the length of dynamic arrays
+	defaultMaxItems = 3
+	// defaultMaxBytes is the default upper bound of the length of dynamic bytes and strings
+	defaultMaxBytes = 32
+	// edgeCaseRatio is the inverse probability of generating an edge value for integers
+	edgeCaseRatio = 4
+)
+
+// generatorCharset lists the characters of generated strings
+const generatorCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 -_."
+
+// GeneratorOption contains configuration options for the Generator
+type GeneratorOption struct {
+	// Seed initializes the pseudo-random generator, equal seeds generate equal values
+	Seed int64
+	// MinItems is the lower bound of the length of dynamic arrays
+	MinItems int
+	// MaxItems is the upper bound of the length of dynamic arrays, 3 by default
+	MaxItems int
+	// MaxBytes is the upper bound of the length of dynamic bytes and strings, 32 by default
+	MaxBytes int
+}
+
+// Generator produces random values shaped by a schema
+// Values are built with the Ethereum builder options, so they can be passed to AbiElements.Encode
+// Integers cover the full range of their size, with a bias towards the bounds, zero and one
+// A Generator is not safe for concurrent use
+type Generator struct {
+	rand    *rand.Rand
+	builder *builder.Builder
+	opt     GeneratorOption
+}
+
+// NewGenerator creates a new instance of Generator
+// If no options are provided, a seed of 0 and the default bounds are used
+func NewGenerator(opts ...GeneratorOption) *Generator {
+	var opt GeneratorOption
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	if opt.MaxItems <= 0 {
+		opt.MaxItems = max(defaultMaxItems, opt.MinItems)
+	}
+
+	if opt.MaxBytes <= 0 {
+		opt.MaxBytes = defaultMaxBytes
+	}
+
+	return &Generator{
+		rand:    rand.New(rand.NewSource(opt.Seed)),
+		builder: builder.New(EtherBuilderOptions),
+		opt:     opt,
+	}
+}
+
+// Generate produces a random value for every element of the schema
+// Values are pointers to the types built for the elements, the same as Builder.Builds
+// Returns an error if the schema cannot be built or MinItems exceeds MaxItems
+func (g *Generator) Generate(schema types.Elements) ([]any, error) {
+	values := make([]any, len(schema))
+	for i, elem := range schema {
+		value, err := g.GenerateElement(elem)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// GenerateElement produces a random value for a single element
+// The value is a pointer to the type built for the element
+func (g *Generator) GenerateElement(elem types.Element) (any, error) {
+	if g.opt.MinItems < 0 || g.opt.MinItems > g.opt.MaxItems {
+		return nil, fmt.Errorf("invalid array bounds %d to %d", g.opt.MinItems, g.opt.MaxItems)
+	}
+
+	value, err := g.builder.Build(elem)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.generate(elem, reflect.ValueOf(value).Elem()); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// generate fills the target with a random value according to its element
+// Optional elements are always populated, so that values survive an encoding round trip
+func (g *Generator) generate(elem types.Element, target reflect.Value) error {
+	if target.Kind() == reflect.Pointer && target.Type() != bigIntTy {
+		target.Set(reflect.New(target.Type().Elem()))
+		target = target.Elem()
+	}
+
+	switch elem.Type {
+	case types.String:
+		target.SetString(g.generateString())
+		return nil
+	case types.Bool:
+		target.SetBool(g.rand.Intn(2) == 1)
+		return nil
+	case types.Address:
+		var address common.Address
+		g.rand.Read(address[:])
+		target.Set(reflect.ValueOf(address))
+		return nil
+	case types.Bytes:
+		return g.generateBytes(elem, target)
+	case types.Int, types.Uint:
+		return g.generateNumber(elem, target)
+	case types.Array:
+		return g.generateArray(elem, target)
+	case types.Object:
+		return g.generateObject(elem, target)
+	}
+
+	return fmt.Errorf("generator does not support %q", elem.Type)
+}
+
+// generateString produces a string of printable characters of up to MaxBytes characters
+func (g *Generator) generateString() string {
+	buf := make([]byte, g.rand.Intn(g.opt.MaxBytes+1))
+	for i := range buf {
+		buf[i] = generatorCharset[g.rand.Intn(len(generatorCharset))]
+	}
+	return string(buf)
+}
+
+// generateBytes fills a fixed-size byte array, or a byte slice of up to MaxBytes bytes
+func (g *Generator) generateBytes(elem types.Element, target reflect.Value) error {
+	switch {
+	case target.Kind() == reflect.Array && target.Type().Elem().Kind() == reflect.Uint8:
+		buf := make([]byte, target.Len())
+		g.rand.Read(buf)
+		reflect.Copy(target, reflect.ValueOf(buf))
+	case target.Kind() == reflect.Slice && target.Type().Elem().Kind() == reflect.Uint8:
+		buf := make([]byte, g.rand.Intn(g.opt.MaxBytes+1))
+		g.rand.Read(buf)
+		target.SetBytes(buf)
+	default:
+		return fmt.Errorf("cannot generate %s into %s", utils.TypeName(elem), target.Type())
+	}
+
+	return nil
+}
+
+// generateNumber produces an integer within the bounds of the element size and signedness
+// One value out of edgeCaseRatio is picked among the bounds, zero, one and minus one
+func (g *Generator) generateNumber(elem types.Element, target reflect.Value) error {
+	min, max, err := utils.IntegerBounds(elem)
+	if err != nil {
+		return err
+	}
+
+	var number *big.Int
+	if g.rand.Intn(edgeCaseRatio) == 0 {
+		edges := []*big.Int{min, max, big.NewInt(0), big.NewInt(1)}
+		if elem.Type == types.Int {
+			edges = append(edges, big.NewInt(-1))
+		}
+		number = new(big.Int).Set(edges[g.rand.Intn(len(edges))])
+	} else {
+		span := new(big.Int).Sub(max, min)
+		number = new(big.Int).Rand(g.rand, span.Add(span, big.NewInt(1)))
+		number.Add(number, min)
+	}
+
+	switch {
+	case target.Type() == bigIntTy:
+		target.Set(reflect.ValueOf(number))
+	case target.CanInt() && number.IsInt64() && !target.OverflowInt(number.Int64()):
+		target.SetInt(number.Int64())
+	case target.CanUint() && number.IsUint64() && !target.OverflowUint(number.Uint64()):
+		target.SetUint(number.Uint64())
+	default:
+		return fmt.Errorf("cannot generate %s into %s", utils.TypeName(elem), target.Type())
+	}
+
+	return nil
+}
+
+// generateArray fills a slice with Size items for fixed-size arrays,
+// or with MinItems to MaxItems items for dynamic arrays
+func (g *Generator) generateArray(elem types.Element, target reflect.Value) error {
+	if len(elem.Children) != 1 {
+		return fmt.Errorf("array must have one child")
+	}
+
+	length := elem.Size
+	if length <= 0 {
+		length = g.opt.MinItems + g.rand.Intn(g.opt.MaxItems-g.opt.MinItems+1)
+	}
+
+	switch target.Kind() {
+	case reflect.Slice:
+		target.Set(reflect.MakeSlice(target.Type(), length, length))
+	case reflect.Array:
+		if target.Len() != length {
+			return fmt.Errorf("cannot generate %d items into %s", length, target.Type())
+		}
+	default:
+		return fmt.Errorf("cannot generate array into %s", target.Type())
+	}
+
+	for i := 0; i < length; i++ {
+		if err := g.generate(elem.Children[0], target.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateObject fills every field of the struct built for the element
+func (g *Generator) generateObject(elem types.Element, target reflect.Value) error {
+	if target.Kind() != reflect.Struct || target.NumField() != len(elem.Children) {
+		return fmt.Errorf("cannot generate object into %s", target.Type())
+	}
+
+	for i, child := range elem.Children {
+		if err := g.generate(child, target.Field(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
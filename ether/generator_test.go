@@ -0,0 +1,120 @@
+package ether
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_RoundTrip(t *testing.T) {
+	type Testcase struct {
+		Name   string
+		Schema types.Elements
+	}
+
+	testcases := []Testcase{
+		{
+			Name:   "primitives",
+			Schema: types.Elements{{Type: types.String}, {Type: types.Bool}, {Type: types.Address}, {Type: types.Bytes}, {Type: types.Bytes, Size: 32}},
+		},
+		{
+			Name:   "integers",
+			Schema: types.Elements{{Type: types.Uint, Size: 8}, {Type: types.Int, Size: 8}, {Type: types.Uint}, {Type: types.Int, Size: 24}, {Type: types.Uint, Size: 256}, {Type: types.Int, Size: 256}},
+		},
+		{
+			Name: "nested",
+			Schema: types.Elements{
+				{Type: types.Array, Size: 3, Children: types.Elements{{Type: types.Array, Children: types.Elements{{Type: types.Uint, Size: 128}}}}},
+				{Type: types.Object, Children: types.Elements{
+					{Name: "owner", Type: types.Address},
+					{Name: "tags", Type: types.Array, Children: types.Elements{{Type: types.Bytes, Size: 4}}},
+					{Name: "balance", Type: types.Object, Children: types.Elements{{Name: "amount", Type: types.Int, Size: 64}, {Name: "currency", Type: types.String}}},
+				}},
+			},
+		},
+		{
+			Name:   "optional",
+			Schema: types.Elements{{Type: types.String, Optional: true}, {Type: types.Object, Children: types.Elements{{Name: "limit", Type: types.Uint, Size: 16, Optional: true}}}},
+		},
+	}
+
+	parser := NewEtherParser(ParserOption{OptionalPolicy: ZeroOptional})
+	unwelder := NewUnwelder(StringIntegers)
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			args, err := parser.Serialize(tc.Schema)
+			assert.NoError(t, err)
+
+			generator := NewGenerator(GeneratorOption{Seed: 42})
+			for i := 0; i < 50; i++ {
+				values, err := generator.Generate(tc.Schema)
+				assert.NoError(t, err)
+
+				data, err := args.Encode(values...)
+				assert.NoError(t, err)
+
+				decoded, err := args.Decode(data)
+				assert.NoError(t, err)
+
+				expected, err := unwelder.Marshal(tc.Schema, values)
+				assert.NoError(t, err)
+
+				actual, err := unwelder.Marshal(tc.Schema, decoded)
+				assert.NoError(t, err)
+				assert.Equal(t, string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	schema := types.Elements{
+		{Type: types.Int, Size: 24},
+		{Type: types.Array, Children: types.Elements{{Type: types.Bool}}},
+		{Type: types.Array, Size: 5, Children: types.Elements{{Type: types.Bytes}}},
+	}
+
+	t.Run("deterministic", func(t *testing.T) {
+		first, err := NewGenerator(GeneratorOption{Seed: 7}).Generate(schema)
+		assert.NoError(t, err)
+
+		second, err := NewGenerator(GeneratorOption{Seed: 7}).Generate(schema)
+		assert.NoError(t, err)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("bounds", func(t *testing.T) {
+		min, max, err := utils.IntegerBounds(schema[0])
+		assert.NoError(t, err)
+
+		generator := NewGenerator(GeneratorOption{Seed: 1, MinItems: 2, MaxItems: 4, MaxBytes: 8})
+		for i := 0; i < 200; i++ {
+			values, err := generator.Generate(schema)
+			assert.NoError(t, err)
+
+			number := *values[0].(**big.Int)
+			assert.True(t, number.Cmp(min) >= 0 && number.Cmp(max) <= 0, "%s out of int24 range", number)
+
+			items := reflect.ValueOf(values[1]).Elem()
+			assert.True(t, items.Len() >= 2 && items.Len() <= 4, "%d items out of bounds", items.Len())
+
+			fixed := reflect.ValueOf(values[2]).Elem()
+			assert.Equal(t, 5, fixed.Len())
+			for j := 0; j < fixed.Len(); j++ {
+				assert.LessOrEqual(t, fixed.Index(j).Len(), 8)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewGenerator(GeneratorOption{MinItems: 5, MaxItems: 2}).Generate(schema)
+		assert.Error(t, err)
+
+		_, err = NewGenerator().Generate(types.Elements{{Type: types.Float}})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,56 @@
+package ether
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// maxExponentDigits limits the exponent of scientific notation to at most 999
+const maxExponentDigits = 3
+
+// parseInteger parses a decimal, exponent (`1e18`) or hex (`0x..`) quantity into a big.Int
+// Leading and trailing whitespace is ignored and a leading sign is allowed
+// Returns an error if the input is not an integer
+func parseInteger(input string) (*big.Int, error) {
+	s := strings.TrimSpace(input)
+
+	negative := false
+	unsigned := s
+	if strings.HasPrefix(unsigned, "-") || strings.HasPrefix(unsigned, "+") {
+		negative = unsigned[0] == '-'
+		unsigned = unsigned[1:]
+	}
+
+	if strings.HasPrefix(unsigned, "0x") || strings.HasPrefix(unsigned, "0X") {
+		value, ok := new(big.Int).SetString(unsigned[2:], 16)
+		if !ok || strings.HasPrefix(unsigned[2:], "+") || strings.HasPrefix(unsigned[2:], "-") {
+			return nil, fmt.Errorf("invalid hex quantity %q", input)
+		}
+
+		if negative {
+			value.Neg(value)
+		}
+		return value, nil
+	}
+
+	if value, ok := new(big.Int).SetString(s, 10); ok {
+		return value, nil
+	}
+
+	// Guard against exponents like `1e1000000000` which would allocate huge numbers
+	if i := strings.IndexAny(s, "eE"); i >= 0 && len(strings.TrimLeft(s[i+1:], "+-0")) > maxExponentDigits {
+		return nil, fmt.Errorf("exponent of %q is too large", input)
+	}
+
+	rat, ok := new(big.Rat).SetString(s)
+	if !ok || strings.ContainsAny(s, "/") {
+		return nil, fmt.Errorf("invalid integer %q", input)
+	}
+
+	if !rat.IsInt() {
+		return nil, fmt.Errorf("%q is not an integer", input)
+	}
+
+	return new(big.Int).Set(rat.Num()), nil
+}
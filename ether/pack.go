@@ -0,0 +1,229 @@
+package ether
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+// wordSize is the size of an ABI slot
+const wordSize = 32
+
+// packed is the ABI encoding of a single value
+// Static values are inlined in the head of their enclosing tuple,
+// dynamic values are appended to its tail and referenced from the head by their offset
+type packed struct {
+	dynamic bool
+	data    []byte
+}
+
+// isDynamic reports whether the element is encoded in the tail of its enclosing tuple
+func isDynamic(elem types.Element) bool {
+	switch elem.Type {
+	case types.String:
+		return true
+	case types.Bytes:
+		return elem.Size == 0
+	case types.Array:
+		return elem.Size == 0 || len(elem.Children) == 1 && isDynamic(elem.Children[0])
+	case types.Object:
+		for _, child := range elem.Children {
+			if isDynamic(child) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// word left-pads the value into a slot
+func word(value []byte) []byte {
+	return common.LeftPadBytes(value, wordSize)
+}
+
+// lengthWord encodes a length or an offset into a slot
+func lengthWord(n int) []byte {
+	return word(big.NewInt(int64(n)).Bytes())
+}
+
+// packTuple lays out the items as a tuple, heads first and tails after them
+// The tuple is dynamic if any of its items is
+func packTuple(items []packed) packed {
+	headSize, tailSize, dynamic := 0, 0, false
+	for _, item := range items {
+		if item.dynamic {
+			headSize += wordSize
+			tailSize += len(item.data)
+			dynamic = true
+			continue
+		}
+		headSize += len(item.data)
+	}
+
+	data := make([]byte, 0, headSize+tailSize)
+	offset := headSize
+	for _, item := range items {
+		if item.dynamic {
+			data = append(data, lengthWord(offset)...)
+			offset += len(item.data)
+			continue
+		}
+		data = append(data, item.data...)
+	}
+
+	for _, item := range items {
+		if item.dynamic {
+			data = append(data, item.data...)
+		}
+	}
+
+	return packed{dynamic: dynamic, data: data}
+}
+
+// packArray lays out the items of an array, dynamic arrays are prefixed with their length
+func packArray(elem types.Element, items []packed) packed {
+	body := packTuple(items)
+	if elem.Size > 0 {
+		return packed{dynamic: isDynamic(elem), data: body.data}
+	}
+
+	return packed{dynamic: true, data: append(lengthWord(len(items)), body.data...)}
+}
+
+// packZero encodes the zero value of the element, used for missing optional values
+func packZero(elem types.Element) packed {
+	switch elem.Type {
+	case types.String:
+		return packed{dynamic: true, data: make([]byte, wordSize)}
+	case types.Bytes:
+		return packed{dynamic: elem.Size == 0, data: make([]byte, wordSize)}
+	case types.Array:
+		if elem.Size == 0 || len(elem.Children) != 1 {
+			return packed{dynamic: true, data: make([]byte, wordSize)}
+		}
+
+		items := make([]packed, elem.Size)
+		for i := range items {
+			items[i] = packZero(elem.Children[0])
+		}
+		return packArray(elem, items)
+	case types.Object:
+		items := make([]packed, len(elem.Children))
+		for i, child := range elem.Children {
+			items[i] = packZero(child)
+		}
+		return packTuple(items)
+	}
+
+	return packed{data: make([]byte, wordSize)}
+}
+
+// packInteger encodes an integer in two's complement after checking it fits the element
+func packInteger(elem types.Element, number *big.Int, path string, value any) (packed, error) {
+	if err := utils.CheckInteger(elem, number); err != nil {
+		return packed{}, integerFailure(elem, path, value, err)
+	}
+
+	return packed{data: math.U256Bytes(new(big.Int).Set(number))}, nil
+}
+
+// packBytes encodes bytes, right-padded into a slot when fixed-size and prefixed with their length otherwise
+func packBytes(elem types.Element, value []byte, path string, raw any) (packed, error) {
+	if elem.Size < 0 || elem.Size > 32 {
+		return packed{}, failure(elem, path, CodeInvalidSchema, nil, "invalid bytes size %d", elem.Size)
+	}
+
+	if elem.Size > 0 {
+		if len(value) != elem.Size {
+			return packed{}, failure(elem, path, CodeInvalidLength, raw, "expected %d bytes, got %d", elem.Size, len(value))
+		}
+		return packed{data: common.RightPadBytes(value, wordSize)}, nil
+	}
+
+	return packDynamic(value), nil
+}
+
+// packDynamic encodes dynamic bytes or a string as its length followed by its right-padded content
+func packDynamic(value []byte) packed {
+	padded := (len(value) + wordSize - 1) / wordSize * wordSize
+	data := make([]byte, wordSize+padded)
+	copy(data, lengthWord(len(value)))
+	copy(data[wordSize:], value)
+	return packed{dynamic: true, data: data}
+}
+
+// packScalar encodes a decoded JSON value of a non-composite element
+// Integers are parsed by utils.ParseInteger, bytes and addresses are 0x-prefixed hex,
+// and fixed-size bytes may also be arrays of byte numbers
+func packScalar(elem types.Element, value any, path string) (packed, error) {
+	switch elem.Type {
+	case types.String:
+		s, ok := value.(string)
+		if !ok {
+			return packed{}, mismatch(elem, path, value)
+		}
+		return packDynamic([]byte(s)), nil
+	case types.Int, types.Uint:
+		number, err := utils.ParseInteger(elem, value)
+		if err != nil {
+			return packed{}, integerFailure(elem, path, value, err)
+		}
+		return packed{data: math.U256Bytes(number)}, nil
+	case types.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return packed{}, mismatch(elem, path, value)
+		}
+
+		data := make([]byte, wordSize)
+		if b {
+			data[wordSize-1] = 1
+		}
+		return packed{data: data}, nil
+	case types.Address:
+		s, ok := value.(string)
+		if !ok {
+			return packed{}, mismatch(elem, path, value)
+		}
+
+		if !strings.HasPrefix(s, "0x") || len(s) != 42 || !isHex(s[2:]) {
+			return packed{}, failure(elem, path, CodeInvalidFormat, value, "expected a 0x-prefixed 20-byte hex address")
+		}
+		return packed{data: word(common.HexToAddress(s).Bytes())}, nil
+	case types.Bytes:
+		switch val := value.(type) {
+		case string:
+			data, err := hexutil.Decode(val)
+			if err != nil {
+				return packed{}, failure(elem, path, CodeInvalidFormat, value, "expected 0x-prefixed hex with an even number of digits")
+			}
+			return packBytes(elem, data, path, value)
+		case []any:
+			if elem.Size == 0 {
+				return packed{}, mismatch(elem, path, value)
+			}
+
+			data := make([]byte, len(val))
+			for i, item := range val {
+				n, ok := item.(json.Number)
+				b, valid := new(big.Int).SetString(n.String(), 10)
+				if !ok || !valid || b.Sign() < 0 || b.BitLen() > 8 {
+					return packed{}, failure(elem, utils.JSONPointer(path, i), CodeInvalidFormat, item, "expected a byte between 0 and 255")
+				}
+				data[i] = byte(b.Uint64())
+			}
+			return packBytes(elem, data, path, value)
+		}
+		return packed{}, mismatch(elem, path, value)
+	case types.Array, types.Object:
+		return packed{}, mismatch(elem, path, value)
+	}
+
+	return packed{}, failure(elem, path, CodeUnsupportedType, value, "type %q is not supported by the EVM", elem.Type)
+}
@@ -0,0 +1,237 @@
+package ether
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+const (
+	// ErrorSignature is the signature of the error raised by `require` and `revert` with a reason string
+	ErrorSignature = "Error(string)"
+	// PanicSignature is the signature of the error raised by failing assertions and runtime checks
+	PanicSignature = "Panic(uint256)"
+)
+
+// PanicCodes maps the panic codes emitted by the Solidity compiler to their meaning
+var PanicCodes = map[uint64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to zero-initialized function",
+}
+
+// RevertError is the structured error decoded from the data returned by a reverted call
+// It implements the error interface and renders as JSON through MarshalJSON
+type RevertError struct {
+	// Name is the error name, `Error` and `Panic` for the builtin errors, empty for a revert without data
+	Name string
+	// Signature is the canonical signature of the error, e.g. `InsufficientBalance(uint256,uint256)`
+	Signature string
+	// Selector is the 4-byte selector the revert data starts with
+	Selector []byte
+	// Reason is the message of `Error(string)` or the meaning of the `Panic(uint256)` code
+	Reason string
+	// Code is the panic code of `Panic(uint256)`, nil for other errors
+	Code *big.Int
+	// Inputs is the schema of the error arguments
+	Inputs types.Elements
+	// Values are the decoded error arguments in the order of the inputs
+	Values []any
+
+	// args holds the JSON-ready arguments keyed by the input names
+	args utils.OrderedObject
+}
+
+// Error describes the revert, e.g. `execution reverted: insufficient allowance`
+func (e *RevertError) Error() string {
+	switch {
+	case e.Name == "":
+		return "execution reverted"
+	case e.Signature == PanicSignature:
+		return fmt.Sprintf("execution reverted: panic 0x%x (%s)", e.Code, e.Reason)
+	case e.Signature == ErrorSignature:
+		return fmt.Sprintf("execution reverted: %s", e.Reason)
+	}
+
+	args, err := json.Marshal(e.args)
+	if err != nil {
+		return fmt.Sprintf("execution reverted: %s", e.Name)
+	}
+	return fmt.Sprintf("execution reverted: %s %s", e.Name, args)
+}
+
+// MarshalJSON renders the error with its arguments keyed by the input names
+func (e *RevertError) MarshalJSON() ([]byte, error) {
+	result := utils.OrderedObject{
+		{Key: "name", Value: e.Name},
+		{Key: "signature", Value: e.Signature},
+		{Key: "selector", Value: hexutil.Encode(e.Selector)},
+	}
+
+	if e.Reason != "" {
+		result = append(result, utils.OrderedField{Key: "reason", Value: e.Reason})
+	}
+
+	args := e.args
+	if args == nil {
+		args = utils.OrderedObject{}
+	}
+	result = append(result, utils.OrderedField{Key: "args", Value: args})
+
+	return json.Marshal(result)
+}
+
+// customError is an error registered in the RevertDecoder
+type customError struct {
+	name      string
+	signature string
+	selector  []byte
+	inputs    types.Elements
+	args      AbiElements
+}
+
+// RevertDecoder decodes revert data into RevertError values
+// The builtin `Error(string)` and `Panic(uint256)` are always recognized,
+// custom errors must be registered with their schema first
+type RevertDecoder struct {
+	unwelder *Unwelder
+	errors   map[[4]byte]*customError
+}
+
+// NewRevertDecoder creates a new instance of RevertDecoder with the builtin errors registered
+// Integers of the decoded arguments are rendered in JSON according to the format
+func NewRevertDecoder(format IntegerFormat) *RevertDecoder {
+	d := &RevertDecoder{
+		unwelder: NewUnwelder(format),
+		errors:   make(map[[4]byte]*customError),
+	}
+
+	// Builtin errors have valid schemas, registration cannot fail
+	_ = d.Register("Error", types.Elements{{Name: "message", Type: types.String}})
+	_ = d.Register("Panic", types.Elements{{Name: "code", Type: types.Uint, Size: 256}})
+	return d
+}
+
+// Register adds a custom error with its schema to the decoder
+// Returns an error if the schema cannot be serialized or its selector is already registered
+func (d *RevertDecoder) Register(name string, inputs types.Elements) error {
+	signature, err := FormatSignature(name, inputs)
+	if err != nil {
+		return err
+	}
+
+	args, err := NewEtherParser().Serialize(inputs)
+	if err != nil {
+		return fmt.Errorf("error %q: %w", signature, err)
+	}
+
+	var selector [4]byte
+	copy(selector[:], crypto.Keccak256([]byte(signature)))
+
+	if existing, ok := d.errors[selector]; ok {
+		return fmt.Errorf("error %q collides with %q on selector %s", signature, existing.signature, hexutil.Encode(selector[:]))
+	}
+
+	d.errors[selector] = &customError{
+		name:      name,
+		signature: signature,
+		selector:  selector[:],
+		inputs:    inputs,
+		args:      args,
+	}
+	return nil
+}
+
+// RegisterSignature adds a custom error declared by its signature, e.g. `InsufficientBalance(uint256 available, uint256 required)`
+func (d *RevertDecoder) RegisterSignature(signature string) error {
+	name, inputs, err := ParseSignature(signature)
+	if err != nil {
+		return err
+	}
+
+	return d.Register(name, inputs)
+}
+
+// Decode decodes revert data into a RevertError
+// Empty data yields a RevertError without name, as produced by a bare `revert()`
+// Returns an error if the selector is unknown or the arguments cannot be decoded
+func (d *RevertDecoder) Decode(data []byte) (*RevertError, error) {
+	if len(data) == 0 {
+		return &RevertError{}, nil
+	}
+
+	if len(data) < 4 {
+		return nil, fmt.Errorf("revert data %s is shorter than a selector", hexutil.Encode(data))
+	}
+
+	var selector [4]byte
+	copy(selector[:], data)
+
+	custom, ok := d.errors[selector]
+	if !ok {
+		return nil, fmt.Errorf("unknown revert selector %s", hexutil.Encode(selector[:]))
+	}
+
+	values, err := custom.args.Decode(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %q: %w", custom.signature, err)
+	}
+
+	args, err := d.unwelder.convertNamed(custom.inputs, values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %q: %w", custom.signature, err)
+	}
+
+	result := &RevertError{
+		Name:      custom.name,
+		Signature: custom.signature,
+		Selector:  bytes.Clone(custom.selector),
+		Inputs:    custom.inputs,
+		Values:    values,
+		args:      args,
+	}
+
+	switch custom.signature {
+	case ErrorSignature:
+		result.Reason = values[0].(string)
+	case PanicSignature:
+		result.Code = values[0].(*big.Int)
+		result.Reason = panicReason(result.Code)
+	}
+
+	return result, nil
+}
+
+// Unweld decodes revert data and renders the resulting RevertError as JSON
+func (d *RevertDecoder) Unweld(data []byte) ([]byte, error) {
+	revert, err := d.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(revert)
+}
+
+// panicReason returns the meaning of a panic code, or a generic description for unknown codes
+func panicReason(code *big.Int) string {
+	if code.IsUint64() {
+		if reason, ok := PanicCodes[code.Uint64()]; ok {
+			return reason
+		}
+	}
+
+	return "unknown panic code"
+}
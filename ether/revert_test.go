@@ -0,0 +1,99 @@
+package ether
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevertDecoder_Decode(t *testing.T) {
+	type Testcase struct {
+		Name     string
+		Data     []byte
+		Error    string
+		Expected string
+	}
+
+	decoder := NewRevertDecoder(SafeIntegers)
+	assert.NoError(t, decoder.RegisterSignature("InsufficientBalance(uint256 available, uint256 required)"))
+	assert.NoError(t, decoder.Register("Unauthorized", types.Elements{{Type: types.Address}}))
+
+	testcases := []Testcase{
+		{
+			Name:     "error-string",
+			Data:     hexutil.MustDecode("0x08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000001d45524332303a20696e73756666696369656e7420616c6c6f77616e636500000000000000000000"),
+			Error:    "execution reverted: ERC20: insufficient allowance",
+			Expected: `{"name":"Error","signature":"Error(string)","selector":"0x08c379a0","reason":"ERC20: insufficient allowance","args":{"message":"ERC20: insufficient allowance"}}`,
+		},
+		{
+			Name:     "panic",
+			Data:     hexutil.MustDecode("0x4e487b710000000000000000000000000000000000000000000000000000000000000011"),
+			Error:    "execution reverted: panic 0x11 (arithmetic overflow or underflow)",
+			Expected: `{"name":"Panic","signature":"Panic(uint256)","selector":"0x4e487b71","reason":"arithmetic overflow or underflow","args":{"code":"17"}}`,
+		},
+		{
+			Name:     "unknown-panic-code",
+			Data:     hexutil.MustDecode("0x4e487b710000000000000000000000000000000000000000000000000000000000000099"),
+			Error:    "execution reverted: panic 0x99 (unknown panic code)",
+			Expected: `{"name":"Panic","signature":"Panic(uint256)","selector":"0x4e487b71","reason":"unknown panic code","args":{"code":"153"}}`,
+		},
+		{
+			Name:     "custom-error",
+			Data:     encodeRevert(t, "InsufficientBalance", types.Elements{{Type: types.Uint, Size: 256}, {Type: types.Uint, Size: 256}}, big.NewInt(1), big.NewInt(2)),
+			Error:    `execution reverted: InsufficientBalance {"available":"1","required":"2"}`,
+			Expected: `{"name":"InsufficientBalance","signature":"InsufficientBalance(uint256,uint256)","selector":"0xcf479181","args":{"available":"1","required":"2"}}`,
+		},
+		{
+			Name:     "unnamed-argument",
+			Data:     encodeRevert(t, "Unauthorized", types.Elements{{Type: types.Address}}, common.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266")),
+			Error:    `execution reverted: Unauthorized {"arg0":"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"}`,
+			Expected: `{"name":"Unauthorized","signature":"Unauthorized(address)","selector":"0x8e4a23d6","args":{"arg0":"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"}}`,
+		},
+		{
+			Name:     "empty",
+			Data:     []byte{},
+			Error:    "execution reverted",
+			Expected: `{"name":"","signature":"","selector":"0x","args":{}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			revert, err := decoder.Decode(tc.Data)
+			assert.NoError(t, err)
+			assert.EqualError(t, revert, tc.Error)
+
+			actual, err := decoder.Unweld(tc.Data)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, string(actual))
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := decoder.Decode(hexutil.MustDecode("0xdeadbeef"))
+		assert.ErrorContains(t, err, "unknown revert selector")
+
+		_, err = decoder.Decode(hexutil.MustDecode("0x08c3"))
+		assert.Error(t, err)
+
+		_, err = decoder.Decode(hexutil.MustDecode("0x08c379a0"))
+		assert.Error(t, err)
+
+		err = decoder.RegisterSignature("Error(string reason)")
+		assert.ErrorContains(t, err, "collides")
+	})
+}
+
+// encodeRevert encodes the revert data of the named error
+func encodeRevert(t *testing.T, name string, inputs types.Elements, values ...any) []byte {
+	args, err := NewEtherParser().Serialize(inputs)
+	assert.NoError(t, err)
+
+	data, err := args.EncodeCall(name, values...)
+	assert.NoError(t, err)
+	return data
+}
@@ -0,0 +1,381 @@
+package ether
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ideatru/welder/types"
+)
+
+// dataLocations lists the Solidity keywords that may follow a parameter type and are ignored
+var dataLocations = map[string]struct{}{
+	"memory":   {},
+	"calldata": {},
+	"storage":  {},
+	"payable":  {},
+}
+
+// ParseSignature parses a function signature such as `transfer(address to, uint256 amount)`
+// Returns the function name and its parameters, or an error if the signature is malformed
+func ParseSignature(signature string) (string, types.Elements, error) {
+	signature = strings.TrimSpace(signature)
+
+	open := strings.Index(signature, "(")
+	if open <= 0 {
+		return "", nil, fmt.Errorf("invalid function signature %q", signature)
+	}
+
+	name := strings.TrimSpace(signature[:open])
+	if !isIdentifier(name) {
+		return "", nil, fmt.Errorf("invalid function name %q", name)
+	}
+
+	p := &signatureParser{input: signature, pos: open + 1}
+	elements, err := p.parseList(')')
+	if err != nil {
+		return "", nil, err
+	}
+
+	if p.skipSpaces(); p.pos != len(p.input) {
+		return "", nil, p.errorf("unexpected trailing characters")
+	}
+
+	return name, nameComponents(elements), nil
+}
+
+// ParseParameters parses a canonical or human-readable Solidity parameter list into types.Elements
+// Both `address,uint256[]` and `(address owner, uint256[] amounts)` are accepted,
+// while `(address owner, uint256[] amounts)[]` is a single parameter of tuple array type
+// Unnamed tuple components are named `arg0`, `arg1`, ... so that they remain addressable in JSON
+func ParseParameters(params string) (types.Elements, error) {
+	p := &signatureParser{input: strings.TrimSpace(params)}
+	if p.input == "" || p.input == "()" {
+		return types.Elements{}, nil
+	}
+
+	elements, err := p.parseList(0)
+	if err != nil {
+		return nil, err
+	}
+
+	// A single unnamed tuple wrapping the whole input is the parameter list itself
+	if len(elements) == 1 && elements[0].Type == types.Object && elements[0].Name == "" &&
+		strings.HasPrefix(p.input, "(") && strings.HasSuffix(p.input, ")") {
+		elements = elements[0].Children
+	}
+
+	return nameComponents(elements), nil
+}
+
+// FormatSignature renders the canonical function signature of the elements, e.g. `transfer(address,uint256)`
+// Returns an error if the name is empty or an element cannot be represented in Solidity
+func FormatSignature(name string, elements types.Elements) (string, error) {
+	name = strings.TrimSpace(name)
+	if !isIdentifier(name) {
+		return "", fmt.Errorf("invalid function name %q", name)
+	}
+
+	params, err := FormatParameters(elements)
+	if err != nil {
+		return "", err
+	}
+
+	return name + params, nil
+}
+
+// FormatParameters renders the canonical parameter list of the elements, e.g. `(string,(address,uint256))`
+// Integers without a size are rendered as 64 bits, the same way EtherParser serializes them
+func FormatParameters(elements types.Elements) (string, error) {
+	parts := make([]string, len(elements))
+	for i, elem := range elements {
+		part, err := formatType(elem)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = part
+	}
+
+	return "(" + strings.Join(parts, ",") + ")", nil
+}
+
+// formatType renders the canonical Solidity type of a single element
+func formatType(elem types.Element) (string, error) {
+	switch elem.Type {
+	case types.String:
+		return "string", nil
+	case types.Bool:
+		return "bool", nil
+	case types.Address:
+		return "address", nil
+	case types.Bytes:
+		if elem.Size <= 0 {
+			return "bytes", nil
+		}
+		return fmt.Sprintf("bytes%d", elem.Size), nil
+	case types.Int, types.Uint:
+		if elem.Size <= 0 {
+			return fmt.Sprintf("%s64", elem.Type), nil
+		}
+		return fmt.Sprintf("%s%d", elem.Type, elem.Size), nil
+	case types.Array:
+		if len(elem.Children) != 1 {
+			return "", fmt.Errorf("array must have one child")
+		}
+
+		child, err := formatType(elem.Children[0])
+		if err != nil {
+			return "", err
+		}
+
+		if elem.Size > 0 {
+			return fmt.Sprintf("%s[%d]", child, elem.Size), nil
+		}
+		return child + "[]", nil
+	case types.Object:
+		if len(elem.Children) == 0 {
+			return "", fmt.Errorf("object must have at least one child")
+		}
+		return FormatParameters(elem.Children)
+	}
+
+	return "", fmt.Errorf("solidity does not support %q", elem.Type)
+}
+
+// signatureParser is a recursive descent parser over Solidity parameter lists
+type signatureParser struct {
+	input string
+	pos   int
+}
+
+// parseList parses comma-separated parameters until the closing character
+// A closing character of 0 means the list runs until the end of the input
+func (p *signatureParser) parseList(closing byte) (types.Elements, error) {
+	elements := make(types.Elements, 0)
+
+	if p.skipSpaces(); closing != 0 && p.peek() == closing {
+		p.pos++
+		return elements, nil
+	}
+
+	for {
+		elem, err := p.parseParameter()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, elem)
+
+		p.skipSpaces()
+		switch {
+		case p.peek() == ',':
+			p.pos++
+		case closing == 0 && p.pos == len(p.input):
+			return elements, nil
+		case closing != 0 && p.peek() == closing:
+			p.pos++
+			return elements, nil
+		default:
+			return nil, p.errorf("expected ',' or end of parameter list")
+		}
+	}
+}
+
+// parseParameter parses a type followed by optional data location keywords and a name
+// The `indexed` keyword of event parameters marks the element as Indexed
+func (p *signatureParser) parseParameter() (types.Element, error) {
+	elem, err := p.parseType()
+	if err != nil {
+		return types.Element{}, err
+	}
+
+	for {
+		p.skipSpaces()
+		start := p.pos
+		word := p.readIdentifier()
+		if word == "" {
+			return elem, nil
+		}
+
+		if _, ok := dataLocations[word]; ok {
+			continue
+		}
+
+		if word == "indexed" && elem.Name == "" {
+			elem.Indexed = true
+			continue
+		}
+
+		if elem.Name != "" {
+			p.pos = start
+			return types.Element{}, p.errorf("unexpected identifier %q", word)
+		}
+		elem.Name = word
+	}
+}
+
+// parseType parses an elementary type, a tuple, and any trailing array suffixes
+func (p *signatureParser) parseType() (types.Element, error) {
+	p.skipSpaces()
+
+	var (
+		elem types.Element
+		err  error
+	)
+
+	start := p.pos
+	word := p.readIdentifier()
+	switch {
+	case word == "" && p.peek() == '(', word == "tuple" && p.peek() == '(':
+		elem, err = p.parseTuple()
+	case word == "":
+		return types.Element{}, p.errorf("expected a type")
+	default:
+		elem, err = elementaryType(word)
+		if err != nil {
+			p.pos = start
+			return types.Element{}, p.errorf("%v", err)
+		}
+	}
+
+	if err != nil {
+		return types.Element{}, err
+	}
+
+	return p.parseArraySuffixes(elem)
+}
+
+// parseTuple parses a parenthesized list of components into an object element
+func (p *signatureParser) parseTuple() (types.Element, error) {
+	p.pos++ // consume '('
+
+	children, err := p.parseList(')')
+	if err != nil {
+		return types.Element{}, err
+	}
+
+	if len(children) == 0 {
+		return types.Element{}, p.errorf("tuple must have at least one component")
+	}
+
+	return types.Element{Type: types.Object, Children: children}, nil
+}
+
+// parseArraySuffixes wraps the element into arrays for every `[]` or `[N]` suffix
+// The last suffix is the outermost array, e.g. `uint256[3][]` is a slice of uint256[3]
+func (p *signatureParser) parseArraySuffixes(elem types.Element) (types.Element, error) {
+	for p.skipSpaces(); p.peek() == '['; p.skipSpaces() {
+		end := strings.IndexByte(p.input[p.pos:], ']')
+		if end < 0 {
+			return types.Element{}, p.errorf("unterminated array suffix")
+		}
+
+		length := strings.TrimSpace(p.input[p.pos+1 : p.pos+end])
+		array := types.Element{Type: types.Array, Children: types.Elements{elem}}
+		if length != "" {
+			size, err := strconv.Atoi(length)
+			if err != nil || size <= 0 {
+				return types.Element{}, p.errorf("invalid array length %q", length)
+			}
+			array.Size = size
+		}
+
+		p.pos += end + 1
+		elem = array
+	}
+
+	return elem, nil
+}
+
+// readIdentifier consumes an identifier made of letters, digits, `_` and `$`
+func (p *signatureParser) readIdentifier() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := rune(p.input[p.pos])
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '$' {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// skipSpaces consumes any whitespace at the current position
+func (p *signatureParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// peek returns the current character, or 0 at the end of the input
+func (p *signatureParser) peek() byte {
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+// errorf creates an error pointing at the current position of the parser
+func (p *signatureParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid parameters %q at offset %d: %s", p.input, p.pos, fmt.Sprintf(format, args...))
+}
+
+// elementaryType converts a Solidity elementary type name into an element
+// Unlike types.ParseType, `uint` and `int` follow Solidity and stand for 256 bits
+func elementaryType(name string) (types.Element, error) {
+	switch name {
+	case "uint":
+		return types.Element{Type: types.Uint, Size: 256}, nil
+	case "int":
+		return types.Element{Type: types.Int, Size: 256}, nil
+	case "string", "bytes", "address", "bool", "byte":
+		return types.ParseType(name)
+	}
+
+	elem, err := types.ParseType(name)
+	if err != nil || elem.Size == 0 || (elem.Type != types.Int && elem.Type != types.Uint && elem.Type != types.Bytes) {
+		return types.Element{}, fmt.Errorf("unsupported type %q", name)
+	}
+
+	switch {
+	case (elem.Type == types.Int || elem.Type == types.Uint) && (elem.Size > 256 || elem.Size%8 != 0):
+		return types.Element{}, fmt.Errorf("invalid integer type %q", name)
+	case elem.Type == types.Bytes && elem.Size > 32:
+		return types.Element{}, fmt.Errorf("invalid bytes type %q", name)
+	}
+
+	return elem, nil
+}
+
+// nameComponents names unnamed tuple components `arg0`, `arg1`, ... in place
+// Top-level parameters may stay unnamed, but object fields must be addressable in JSON
+func nameComponents(elements types.Elements) types.Elements {
+	for i := range elements {
+		switch elements[i].Type {
+		case types.Object:
+			for j := range elements[i].Children {
+				if elements[i].Children[j].Name == "" {
+					elements[i].Children[j].Name = fmt.Sprintf("arg%d", j)
+				}
+			}
+			nameComponents(elements[i].Children)
+		case types.Array:
+			nameComponents(elements[i].Children)
+		}
+	}
+	return elements
+}
+
+// isIdentifier reports whether s is a valid Solidity identifier
+func isIdentifier(s string) bool {
+	if s == "" || unicode.IsDigit(rune(s[0])) {
+		return false
+	}
+
+	for _, c := range s {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '$' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+package ether
+
+import (
+	"testing"
+
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseParameters(t *testing.T) {
+	type Testcase struct {
+		Name      string
+		Input     string
+		Expected  types.Elements
+		Canonical string
+	}
+
+	testcases := []Testcase{
+		{
+			Name:      "canonical-list",
+			Input:     "string,(address,string,(uint256,string))",
+			Expected:  types.Elements{{Type: types.String}, {Type: types.Object, Children: types.Elements{{Name: "arg0", Type: types.Address}, {Name: "arg1", Type: types.String}, {Name: "arg2", Type: types.Object, Children: types.Elements{{Name: "arg0", Type: types.Uint, Size: 256}, {Name: "arg1", Type: types.String}}}}}},
+			Canonical: "(string,(address,string,(uint256,string)))",
+		},
+		{
+			Name:      "parenthesized-named-list",
+			Input:     "(address owner, uint256[] memory amounts, bytes32 salt)",
+			Expected:  types.Elements{{Name: "owner", Type: types.Address}, {Name: "amounts", Type: types.Array, Children: types.Elements{{Type: types.Uint, Size: 256}}}, {Name: "salt", Type: types.Bytes, Size: 32}},
+			Canonical: "(address,uint256[],bytes32)",
+		},
+		{
+			Name:      "tuple-array",
+			Input:     "(address owner, uint256[] amounts)[]",
+			Expected:  types.Elements{{Type: types.Array, Children: types.Elements{{Type: types.Object, Children: types.Elements{{Name: "owner", Type: types.Address}, {Name: "amounts", Type: types.Array, Children: types.Elements{{Type: types.Uint, Size: 256}}}}}}}},
+			Canonical: "((address,uint256[])[])",
+		},
+		{
+			Name:      "tuple-keyword,uint-alias,nested-arrays",
+			Input:     "tuple(uint a, int b)[2] pairs, uint8[3][] matrix, bool",
+			Expected:  types.Elements{{Name: "pairs", Type: types.Array, Size: 2, Children: types.Elements{{Type: types.Object, Children: types.Elements{{Name: "a", Type: types.Uint, Size: 256}, {Name: "b", Type: types.Int, Size: 256}}}}}, {Name: "matrix", Type: types.Array, Children: types.Elements{{Type: types.Array, Size: 3, Children: types.Elements{{Type: types.Uint, Size: 8}}}}}, {Type: types.Bool}},
+			Canonical: "((uint256,int256)[2],uint8[3][],bool)",
+		},
+		{
+			Name:      "indexed-event-parameters",
+			Input:     "address indexed from, address indexed to, uint256 value",
+			Expected:  types.Elements{{Name: "from", Type: types.Address, Indexed: true}, {Name: "to", Type: types.Address, Indexed: true}, {Name: "value", Type: types.Uint, Size: 256}},
+			Canonical: "(address,address,uint256)",
+		},
+		{
+			Name:      "empty",
+			Input:     "()",
+			Expected:  types.Elements{},
+			Canonical: "()",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := ParseParameters(tc.Input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+
+			canonical, err := FormatParameters(actual)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Canonical, canonical)
+		})
+	}
+}
+
+func TestParseParametersInvalid(t *testing.T) {
+	testcases := []string{
+		"uint7",
+		"bytes33",
+		"integer",
+		"address owner spender",
+		"(address,uint256",
+		"uint256[0]",
+		"address,",
+		"()[]",
+	}
+
+	for _, input := range testcases {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseParameters(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseSignature(t *testing.T) {
+	name, elements, err := ParseSignature("transfer(address to, uint amount)")
+	assert.NoError(t, err)
+	assert.Equal(t, "transfer", name)
+	assert.Equal(t, types.Elements{{Name: "to", Type: types.Address}, {Name: "amount", Type: types.Uint, Size: 256}}, elements)
+
+	signature, err := FormatSignature(name, elements)
+	assert.NoError(t, err)
+	assert.Equal(t, "transfer(address,uint256)", signature)
+
+	_, _, err = ParseSignature("transfer(address) extra")
+	assert.Error(t, err)
+
+	_, err = FormatSignature("average", types.Elements{{Type: types.Float}})
+	assert.Error(t, err)
+}
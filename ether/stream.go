@@ -0,0 +1,320 @@
+package ether
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+// StreamEncoder ABI-encodes JSON payloads read token by token from a stream, guided by the schema
+// Values are packed as soon as they are read, the payload is never decoded into Go values,
+// only the encoded output is buffered until the payload is complete
+// Payloads follow the rules of Weld: missing values take their default, missing or null optional values
+// are encoded as zero values and object keys unknown to the schema are skipped
+// A failed payload leaves the reader in the middle of it, so the encoder stops there:
+// every later call returns the first error and the rest of the stream must be discarded
+type StreamEncoder struct {
+	schema  types.Elements
+	decoder *json.Decoder
+	err     error
+}
+
+// NewStreamEncoder creates a StreamEncoder reading payloads from the reader
+// The reader may hold several payloads one after the other, e.g. newline-delimited JSON
+func NewStreamEncoder(schema types.Elements, r io.Reader) *StreamEncoder {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	return &StreamEncoder{schema: schema, decoder: decoder}
+}
+
+// EncodeJSON ABI-encodes a single JSON payload read from the reader
+// The reader must hold nothing but whitespace after the payload
+func EncodeJSON(schema types.Elements, r io.Reader) ([]byte, error) {
+	s := NewStreamEncoder(schema, r)
+	data, err := s.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, ValidationError{Code: CodeInvalidJSON, Message: "invalid character after top-level value"}
+	}
+
+	return data, nil
+}
+
+// More reports whether another payload follows in the stream, always false once a payload failed
+func (s *StreamEncoder) More() bool {
+	return s.err == nil && s.decoder.More()
+}
+
+// Encode reads the next payload and returns its ABI encoding
+// Returns io.EOF when the stream holds no more payloads, or a ValidationError for the first invalid value
+// Once a payload failed, Encode returns the same error without reading further
+func (s *StreamEncoder) Encode() ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	data, err := s.encodePayload()
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.err = err
+	}
+	return data, err
+}
+
+// encodePayload reads the next payload of the stream
+func (s *StreamEncoder) encodePayload() ([]byte, error) {
+	token, err := s.decoder.Token()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+
+	if err != nil {
+		return nil, invalidJSON("", err)
+	}
+
+	if token != json.Delim('[') {
+		return nil, ValidationError{Code: CodeTypeMismatch, Value: token, Message: fmt.Sprintf("payload must be an array, got %s", tokenKind(token))}
+	}
+
+	items := make([]packed, len(s.schema))
+	for i, elem := range s.schema {
+		path := utils.JSONPointer("", i)
+		if !s.decoder.More() {
+			if items[i], err = s.encodeMissing(elem, path); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if items[i], err = s.encode(elem, path); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.decoder.More() {
+		return nil, ValidationError{Path: utils.JSONPointer("", len(s.schema)), Code: CodeUnknownField, Message: fmt.Sprintf("schema only declares %d values", len(s.schema))}
+	}
+
+	if err := s.close(""); err != nil {
+		return nil, err
+	}
+
+	return packTuple(items).data, nil
+}
+
+// EncodeTo reads the next payload and writes its ABI encoding to the writer
+func (s *StreamEncoder) EncodeTo(w io.Writer) error {
+	data, err := s.Encode()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// encode reads the next value of the stream according to its element
+func (s *StreamEncoder) encode(elem types.Element, path string) (packed, error) {
+	token, err := s.decoder.Token()
+	if err != nil {
+		return packed{}, invalidJSON(path, err)
+	}
+
+	switch token {
+	case nil:
+		return s.encodeMissing(elem, path)
+	case json.Delim('['):
+		switch {
+		case elem.Type == types.Array:
+			return s.encodeArray(elem, path)
+		case elem.Type == types.Bytes && elem.Size > 0:
+			return s.encodeByteArray(elem, path)
+		}
+		return packed{}, mismatch(elem, path, []any{})
+	case json.Delim('{'):
+		if elem.Type == types.Object {
+			return s.encodeObject(elem, path)
+		}
+		return packed{}, mismatch(elem, path, map[string]any{})
+	}
+
+	return packScalar(elem, token, path)
+}
+
+// encodeMissing encodes an absent or null value the same way as the Encoder
+func (s *StreamEncoder) encodeMissing(elem types.Element, path string) (packed, error) {
+	return NewEncoder().encodeMissing(elem, path)
+}
+
+// encodeArray reads the items of an array, the opening bracket being consumed
+// Missing items of fixed-size arrays are filled with the child default
+func (s *StreamEncoder) encodeArray(elem types.Element, path string) (packed, error) {
+	if len(elem.Children) != 1 {
+		return packed{}, failure(elem, path, CodeInvalidSchema, nil, "array must have one child")
+	}
+
+	child := elem.Children[0]
+	items := make([]packed, 0, elem.Size)
+	for s.decoder.More() {
+		if elem.Size > 0 && len(items) == elem.Size {
+			return packed{}, failure(elem, path, CodeInvalidLength, nil, "expected %d items, got more", elem.Size)
+		}
+
+		item, err := s.encode(child, utils.JSONPointer(path, len(items)))
+		if err != nil {
+			return packed{}, err
+		}
+		items = append(items, item)
+	}
+
+	if err := s.close(path); err != nil {
+		return packed{}, err
+	}
+
+	if elem.Size > 0 && len(items) < elem.Size {
+		if child.Default == nil {
+			return packed{}, failure(elem, path, CodeInvalidLength, nil, "expected %d items, got %d", elem.Size, len(items))
+		}
+
+		for len(items) < elem.Size {
+			item, err := s.encodeMissing(child, utils.JSONPointer(path, len(items)))
+			if err != nil {
+				return packed{}, err
+			}
+			items = append(items, item)
+		}
+	}
+
+	return packArray(elem, items), nil
+}
+
+// encodeByteArray reads fixed-size bytes written as an array of byte numbers
+func (s *StreamEncoder) encodeByteArray(elem types.Element, path string) (packed, error) {
+	value := make([]any, 0, elem.Size)
+	for s.decoder.More() {
+		token, err := s.decoder.Token()
+		if err != nil {
+			return packed{}, invalidJSON(path, err)
+		}
+
+		if _, ok := token.(json.Delim); ok {
+			return packed{}, failure(elem, utils.JSONPointer(path, len(value)), CodeInvalidFormat, nil, "expected a byte between 0 and 255")
+		}
+		value = append(value, token)
+	}
+
+	if err := s.close(path); err != nil {
+		return packed{}, err
+	}
+
+	return packScalar(elem, value, path)
+}
+
+// encodeObject reads the fields of an object in any order, the opening brace being consumed
+// Fields are packed as they are read and laid out in the order of the schema once the object is closed,
+// fields unknown to the schema are skipped
+func (s *StreamEncoder) encodeObject(elem types.Element, path string) (packed, error) {
+	if len(elem.Children) == 0 {
+		return packed{}, failure(elem, path, CodeInvalidSchema, nil, "object must have at least one child")
+	}
+
+	indexes := make(map[string]int, len(elem.Children))
+	for i, child := range elem.Children {
+		indexes[child.Name] = i
+	}
+
+	items := make([]packed, len(elem.Children))
+	read := make([]bool, len(elem.Children))
+	for s.decoder.More() {
+		token, err := s.decoder.Token()
+		if err != nil {
+			return packed{}, invalidJSON(path, err)
+		}
+
+		key := token.(string)
+		i, ok := indexes[key]
+		if !ok {
+			if err := s.skip(utils.JSONPointer(path, key)); err != nil {
+				return packed{}, err
+			}
+			continue
+		}
+
+		if items[i], err = s.encode(elem.Children[i], utils.JSONPointer(path, key)); err != nil {
+			return packed{}, err
+		}
+		read[i] = true
+	}
+
+	if err := s.close(path); err != nil {
+		return packed{}, err
+	}
+
+	for i, child := range elem.Children {
+		if read[i] {
+			continue
+		}
+
+		var err error
+		if items[i], err = s.encodeMissing(child, utils.JSONPointer(path, child.Name)); err != nil {
+			return packed{}, err
+		}
+	}
+
+	return packTuple(items), nil
+}
+
+// skip consumes the next value of the stream without encoding it
+func (s *StreamEncoder) skip(path string) error {
+	depth := 0
+	for {
+		token, err := s.decoder.Token()
+		if err != nil {
+			return invalidJSON(path, err)
+		}
+
+		switch token {
+		case json.Delim('['), json.Delim('{'):
+			depth++
+		case json.Delim(']'), json.Delim('}'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// close consumes the closing delimiter of the current array or object
+func (s *StreamEncoder) close(path string) error {
+	if _, err := s.decoder.Token(); err != nil {
+		return invalidJSON(path, err)
+	}
+	return nil
+}
+
+// invalidJSON creates a ValidationError for a stream that cannot be read as JSON
+func invalidJSON(path string, err error) ValidationError {
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return ValidationError{Path: path, Code: CodeInvalidJSON, Message: err.Error()}
+}
+
+// tokenKind returns the JSON kind name of a token for error messages
+func tokenKind(token json.Token) string {
+	switch token {
+	case json.Delim('['):
+		return "array"
+	case json.Delim('{'):
+		return "object"
+	}
+	return jsonKind(token)
+}
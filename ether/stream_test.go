@@ -0,0 +1,230 @@
+package ether
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamEncoder_RoundTrip(t *testing.T) {
+	type Testcase struct {
+		Name   string
+		Schema types.Elements
+	}
+
+	testcases := []Testcase{
+		{
+			Name:   "primitives",
+			Schema: types.Elements{{Type: types.String}, {Type: types.Bool}, {Type: types.Address}, {Type: types.Bytes}, {Type: types.Bytes, Size: 32}},
+		},
+		{
+			Name:   "integers",
+			Schema: types.Elements{{Type: types.Uint, Size: 8}, {Type: types.Int, Size: 8}, {Type: types.Uint}, {Type: types.Int, Size: 24}, {Type: types.Uint, Size: 256}, {Type: types.Int, Size: 256}},
+		},
+		{
+			Name: "nested",
+			Schema: types.Elements{
+				{Type: types.Array, Size: 3, Children: types.Elements{{Type: types.Array, Children: types.Elements{{Type: types.Uint, Size: 128}}}}},
+				{Type: types.Object, Children: types.Elements{
+					{Name: "owner", Type: types.Address},
+					{Name: "tags", Type: types.Array, Children: types.Elements{{Type: types.Bytes, Size: 4}}},
+					{Name: "balance", Type: types.Object, Children: types.Elements{{Name: "amount", Type: types.Int, Size: 64}, {Name: "currency", Type: types.String}}},
+				}},
+			},
+		},
+		{
+			Name: "static-tuples",
+			Schema: types.Elements{
+				{Type: types.Array, Size: 2, Children: types.Elements{{Type: types.Object, Children: types.Elements{{Name: "id", Type: types.Uint, Size: 32}, {Name: "flag", Type: types.Bool}}}}},
+				{Type: types.String},
+			},
+		},
+	}
+
+	parser := NewEtherParser(ParserOption{OptionalPolicy: ZeroOptional})
+	unwelder := NewUnwelder(SafeIntegers)
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			args, err := parser.Serialize(tc.Schema)
+			assert.NoError(t, err)
+
+			generator := NewGenerator(GeneratorOption{Seed: 42})
+			for i := 0; i < 50; i++ {
+				values, err := generator.Generate(tc.Schema)
+				assert.NoError(t, err)
+
+				expected, err := args.Encode(values...)
+				assert.NoError(t, err)
+
+				payload, err := unwelder.Marshal(tc.Schema, values)
+				assert.NoError(t, err)
+
+				actual, err := EncodeJSON(tc.Schema, strings.NewReader(string(payload)))
+				assert.NoError(t, err)
+				assert.Equal(t, hex.EncodeToString(expected), hex.EncodeToString(actual), string(payload))
+			}
+		})
+	}
+}
+
+func TestStreamEncoder_Encode(t *testing.T) {
+	schema := types.Elements{
+		{Type: types.Uint, Size: 8},
+		{Type: types.Object, Children: types.Elements{
+			{Name: "name", Type: types.String},
+			{Name: "limit", Type: types.Uint, Size: 16, Default: json.RawMessage(`7`)},
+			{Name: "memo", Type: types.Bytes, Optional: true},
+		}},
+		{Type: types.Array, Size: 3, Children: types.Elements{{Type: types.Bool, Default: json.RawMessage(`true`)}}},
+		{Type: types.Bytes, Size: 2, Optional: true},
+	}
+
+	parser := NewEtherParser(ParserOption{OptionalPolicy: ZeroOptional})
+	args, err := parser.Serialize(schema)
+	assert.NoError(t, err)
+
+	type Account struct {
+		Name  string
+		Limit uint16
+		Memo  []byte
+	}
+
+	type Testcase struct {
+		Name     string
+		Input    string
+		Expected []any
+		Error    string
+	}
+
+	testcases := []Testcase{
+		{
+			Name:     "complete",
+			Input:    `[1, {"memo": "0x0102", "limit": 9, "name": "alice"}, [false, true, false], [1, 2]]`,
+			Expected: []any{uint8(1), Account{"alice", 9, []byte{1, 2}}, [3]bool{false, true, false}, [2]byte{1, 2}},
+		},
+		{
+			Name:     "defaults-and-zero-values",
+			Input:    `["255", {"name": "bob", "memo": null}, [false]]`,
+			Expected: []any{uint8(255), Account{"bob", 7, []byte{}}, [3]bool{false, true, true}, [2]byte{}},
+		},
+		{
+			Name:  "not-an-array",
+			Input: `{"0": 1}`,
+			Error: "/: payload must be an array, got object",
+		},
+		{
+			Name:  "invalid-json",
+			Input: `[1, {"name": "bob"`,
+			Error: "/1: unexpected end of JSON input",
+		},
+		{
+			Name:  "trailing-data",
+			Input: `[1, {"name": "bob"}, []] garbage`,
+			Error: "/: invalid character after top-level value",
+		},
+		{
+			Name:  "trailing-payload",
+			Input: `[1, {"name": "bob"}, []] [2]`,
+			Error: "/: invalid character after top-level value",
+		},
+		{
+			Name:  "out-of-range",
+			Input: `[256, {"name": "bob"}, []]`,
+			Error: "/0: value must be between 0 and 255",
+		},
+		{
+			Name:  "required",
+			Input: `[1, {"limit": 1}, []]`,
+			Error: "/1/name: value is required",
+		},
+		{
+			Name:     "unknown-fields",
+			Input:    `[1, {"extra": {"a": [1, {"b": null}]}, "name": "bob", "tags": [[], {}]}, []]`,
+			Expected: []any{uint8(1), Account{"bob", 7, []byte{}}, [3]bool{true, true, true}, [2]byte{}},
+		},
+		{
+			Name:  "unknown-field-invalid-json",
+			Input: `[1, {"name": "bob", "extra": [1, 2`,
+			Error: "/1/extra: unexpected EOF",
+		},
+		{
+			Name:  "too-many-items",
+			Input: `[1, {"name": "bob"}, [true, true, true, true]]`,
+			Error: "/2: expected 3 items, got more",
+		},
+		{
+			Name:  "too-many-values",
+			Input: `[1, {"name": "bob"}, [], null, 5]`,
+			Error: "/4: schema only declares 4 values",
+		},
+		{
+			Name:  "type-mismatch",
+			Input: `[1, ["bob"], []]`,
+			Error: "/1: expected object, got array",
+		},
+		{
+			Name:  "invalid-byte",
+			Input: `[1, {"name": "bob"}, [], [1, 256]]`,
+			Error: "/3/1: expected a byte between 0 and 255",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := EncodeJSON(schema, strings.NewReader(tc.Input))
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+				return
+			}
+			assert.NoError(t, err)
+
+			expected, err := args.Encode(tc.Expected...)
+			assert.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(expected), hex.EncodeToString(actual))
+		})
+	}
+}
+
+func TestStreamEncoder_Sequence(t *testing.T) {
+	schema := types.Elements{{Type: types.Uint, Size: 8}, {Type: types.String}}
+	encoder := NewStreamEncoder(schema, strings.NewReader("[1, \"a\"]\n[2, \"b\"]\n[3, \"c\"]\n"))
+
+	args, err := NewEtherParser().Serialize(schema)
+	assert.NoError(t, err)
+
+	count := 0
+	for encoder.More() {
+		actual, err := encoder.Encode()
+		assert.NoError(t, err)
+
+		count++
+		expected, err := args.Encode(uint8(count), string(rune('a'+count-1)))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+	assert.Equal(t, 3, count)
+
+	_, err = encoder.Encode()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestStreamEncoder_StickyError(t *testing.T) {
+	schema := types.Elements{{Type: types.Uint, Size: 8}, {Type: types.String}}
+	encoder := NewStreamEncoder(schema, strings.NewReader("[256, \"a\"]\n[2, \"b\"]\n"))
+
+	_, err := encoder.Encode()
+	var validation ValidationError
+	assert.ErrorAs(t, err, &validation)
+	assert.Equal(t, "/0", validation.Path)
+
+	assert.False(t, encoder.More())
+
+	_, next := encoder.Encode()
+	assert.Equal(t, err, next)
+	assert.Equal(t, err, encoder.EncodeTo(io.Discard))
+}
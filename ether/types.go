@@ -2,7 +2,9 @@ package ether
 
 import (
 	"fmt"
+	"math/big"
 	"reflect"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
@@ -12,6 +14,19 @@ import (
 )
 
 var (
+	// bigIntTy represents the reflect type of *big.Int
+	// Used to stop walking into the internals of big integers
+	bigIntTy = reflect.TypeOf(big.NewInt(0))
+
+	// bytesTy represents the reflect type of []byte, the only dynamic bytes type the ABI packer accepts
+	bytesTy = reflect.TypeOf([]byte{})
+
+	// hexBytesTy represents the reflect type of hexutil.Bytes, built for dynamic bytes to marshal them as hex
+	hexBytesTy = reflect.TypeOf(hexutil.Bytes{})
+
+	// packableTypes caches the result of packableType by type
+	packableTypes sync.Map
+
 	// emptyTy represents an empty Ethereum ABI type
 	// Used as a default return value for error cases in encoding/decoding operations
 	emptyTy = abi.Type{}
@@ -57,9 +72,220 @@ func ReflectBytesFn(elem types.Element) (reflect.Type, error) {
 	return reflect.ArrayOf(elem.Size, reflect.TypeOf(byte(0))), nil
 }
 
+// packable returns the value in a form the ABI packer accepts
+// The packer rejects named byte slices and cannot dereference nil pointers, so values holding
+// hexutil.Bytes or nil pointers are copied with []byte and pointers to zero values instead
+// The value itself is never modified
+// Returns an error if a nil pointer sits in a struct with unexported fields, which cannot be copied
+func packable(value any) (any, error) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return value, nil
+	}
+
+	ty := packableType(v.Type())
+	if ty == v.Type() && !hasNilPointers(v) {
+		return value, nil
+	}
+
+	out, err := convertValue(v, ty)
+	if err != nil {
+		return nil, err
+	}
+	return out.Interface(), nil
+}
+
+// packableType returns the type with every hexutil.Bytes replaced with []byte
+// Types without hexutil.Bytes, or that cannot be rebuilt, are returned as is
+func packableType(ty reflect.Type) reflect.Type {
+	if cached, ok := packableTypes.Load(ty); ok {
+		return cached.(reflect.Type)
+	}
+
+	result := ty
+	switch ty.Kind() {
+	case reflect.Slice:
+		if ty == hexBytesTy {
+			result = bytesTy
+		} else if elem := packableType(ty.Elem()); elem != ty.Elem() {
+			result = reflect.SliceOf(elem)
+		}
+	case reflect.Array:
+		if elem := packableType(ty.Elem()); elem != ty.Elem() {
+			result = reflect.ArrayOf(ty.Len(), elem)
+		}
+	case reflect.Pointer:
+		if elem := packableType(ty.Elem()); ty != bigIntTy && elem != ty.Elem() {
+			result = reflect.PointerTo(elem)
+		}
+	case reflect.Struct:
+		if !rebuildable(ty) {
+			break
+		}
+
+		fields := make([]reflect.StructField, ty.NumField())
+		changed := false
+		for i := range fields {
+			fields[i] = ty.Field(i)
+			if field := packableType(fields[i].Type); field != fields[i].Type {
+				fields[i].Type = field
+				changed = true
+			}
+		}
+
+		if changed {
+			result = reflect.StructOf(fields)
+		}
+	}
+
+	packableTypes.Store(ty, result)
+	return result
+}
+
+// rebuildable reports whether every field of the struct type is exported, reflect can only set those
+func rebuildable(ty reflect.Type) bool {
+	for i := 0; i < ty.NumField(); i++ {
+		if !ty.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
+
+// convertValue copies the value into the type returned by packableType for its type
+// Nil pointers are replaced with pointers to zero values, the parts without pointers are shared
+// Structs with unexported fields are shared as they are, unless they hold a nil pointer
+func convertValue(v reflect.Value, ty reflect.Type) (reflect.Value, error) {
+	if v.Type() == ty && !hasPointers(ty) {
+		return v, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(ty), nil
+		}
+
+		if ty == bytesTy {
+			return reflect.ValueOf(v.Bytes()), nil
+		}
+
+		out := reflect.MakeSlice(ty, v.Len(), v.Len())
+		return out, convertItems(v, out)
+	case reflect.Array:
+		out := reflect.New(ty).Elem()
+		return out, convertItems(v, out)
+	case reflect.Pointer:
+		if !v.IsNil() && v.Type() == bigIntTy {
+			return v, nil
+		}
+
+		elem := reflect.New(ty.Elem()).Elem()
+		if !v.IsNil() {
+			elem = v.Elem()
+		}
+
+		converted, err := convertValue(elem, ty.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		out := reflect.New(ty.Elem())
+		out.Elem().Set(converted)
+		return out, nil
+	case reflect.Struct:
+		if v.Type() == bigIntTy.Elem() {
+			return v, nil
+		}
+
+		if !rebuildable(v.Type()) {
+			if hasNilPointers(v) {
+				return reflect.Value{}, fmt.Errorf("cannot encode nil pointers of %s, it has unexported fields", v.Type())
+			}
+			return v, nil
+		}
+
+		out := reflect.New(ty).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			field, err := convertValue(v.Field(i), ty.Field(i).Type)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Field(i).Set(field)
+		}
+		return out, nil
+	case reflect.Interface:
+		if v.IsNil() {
+			return v, nil
+		}
+
+		elem, err := convertValue(v.Elem(), packableType(v.Elem().Type()))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		out := reflect.New(ty).Elem()
+		out.Set(elem)
+		return out, nil
+	}
+
+	return v.Convert(ty), nil
+}
+
+// convertItems converts the items of a slice or an array into the items of out
+func convertItems(v, out reflect.Value) error {
+	for i := 0; i < v.Len(); i++ {
+		item, err := convertValue(v.Index(i), out.Type().Elem())
+		if err != nil {
+			return err
+		}
+		out.Index(i).Set(item)
+	}
+	return nil
+}
+
 // EtherStructTag creates a struct tag for Ethereum ABI and JSON serialization
 // Takes a field name and generates a struct tag with both ABI and JSON tags using that name
 // Format: `abi:"fieldName" json:"fieldName"`
 func EtherStructTag(tag string) reflect.StructTag {
 	return reflect.StructTag(`abi:"` + tag + `" json:"` + tag + `"`)
 }
+
+// hasNilPointers reports whether a value holds a nil pointer, which the ABI packer cannot dereference
+func hasNilPointers(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer:
+		return v.IsNil() || v.Type() != bigIntTy && hasNilPointers(v.Elem())
+	case reflect.Interface:
+		return !v.IsNil() && hasNilPointers(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if hasNilPointers(v.Field(i)) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if !hasPointers(v.Type().Elem()) {
+			return false
+		}
+		for i := 0; i < v.Len(); i++ {
+			if hasNilPointers(v.Index(i)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// hasPointers reports whether values of the type may contain nested pointers
+func hasPointers(ty reflect.Type) bool {
+	switch ty.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Struct:
+		return true
+	case reflect.Slice, reflect.Array:
+		return hasPointers(ty.Elem())
+	}
+
+	return false
+}
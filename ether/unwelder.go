@@ -0,0 +1,231 @@
+package ether
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+// IntegerFormat defines how the Unwelder renders integers in JSON
+type IntegerFormat int
+
+const (
+	// SafeIntegers renders integers wider than 53 bits as decimal strings and the others as numbers
+	// Every value stays exact when parsed by JavaScript clients
+	SafeIntegers IntegerFormat = iota
+	// StringIntegers renders every integer as a decimal string
+	StringIntegers
+	// NumberIntegers renders every integer as a JSON number with all of its digits
+	NumberIntegers
+)
+
+// maxSafeIntegerBits is the width of the largest integer a float64 represents exactly
+const maxSafeIntegerBits = 53
+
+// Unwelder converts decoded ABI values back into JSON shaped by the schema
+// It is the mirror of welding: objects use the schema field names, bytes are hex
+// and addresses are checksummed
+type Unwelder struct {
+	// IntegerFormat defines how integers are rendered, SafeIntegers by default
+	IntegerFormat IntegerFormat
+}
+
+// NewUnwelder creates a new instance of Unwelder
+func NewUnwelder(format IntegerFormat) *Unwelder { return &Unwelder{IntegerFormat: format} }
+
+// Unweld decodes ABI encoded data according to the schema and renders it as JSON
+// Returns the JSON array of the decoded values or an error if decoding fails
+func (u *Unwelder) Unweld(args AbiElements, schema types.Elements, data []byte) ([]byte, error) {
+	values, err := args.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.Marshal(schema, values)
+}
+
+// UnweldEvent decodes the topics and data of a log emitted by the event and renders it as JSON
+// Returns a JSON object keyed by the input names, unnamed inputs being keyed `arg0`, `arg1`, ...
+// Indexed inputs of reference types are rendered as the hex of their keccak256 hash
+func (u *Unwelder) UnweldEvent(event Event, topics [][32]byte, data []byte) ([]byte, error) {
+	values, err := event.Decode(topics, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := u.convertNamed(event.decodedInputs(), values)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(result)
+}
+
+// convertNamed converts values into an object keyed by the names of their inputs
+// Unnamed inputs are keyed `arg0`, `arg1`, ... after their position
+func (u *Unwelder) convertNamed(inputs types.Elements, values []any) (utils.OrderedObject, error) {
+	result := make(utils.OrderedObject, len(inputs))
+	for i, input := range inputs {
+		value, err := u.Convert(input, values[i])
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+
+		key := input.Name
+		if key == "" {
+			key = fmt.Sprintf("arg%d", i)
+		}
+		result[i] = utils.OrderedField{Key: key, Value: value}
+	}
+
+	return result, nil
+}
+
+// Marshal renders Go values shaped by the schema as a JSON array
+// Values may be the result of AbiElements.Decode or of welding
+func (u *Unwelder) Marshal(schema types.Elements, values []any) ([]byte, error) {
+	if len(values) != len(schema) {
+		return nil, fmt.Errorf("schema declares %d values, got %d", len(schema), len(values))
+	}
+
+	result := make([]any, len(values))
+	for i, value := range values {
+		converted, err := u.Convert(schema[i], value)
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		result[i] = converted
+	}
+
+	return json.Marshal(result)
+}
+
+// Convert turns a single Go value into a JSON-ready value shaped by its element
+// Objects are returned as utils.OrderedObject so that fields keep the schema order
+func (u *Unwelder) Convert(elem types.Element, value any) (any, error) {
+	return u.convert(elem, reflect.ValueOf(value))
+}
+
+// convert dispatches to the appropriate type-specific converter based on the element type
+func (u *Unwelder) convert(elem types.Element, v reflect.Value) (any, error) {
+	for v.IsValid() && (v.Kind() == reflect.Interface || (v.Kind() == reflect.Pointer && v.Type() != bigIntTy)) {
+		v = v.Elem()
+	}
+
+	if !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return nil, nil
+	}
+
+	switch elem.Type {
+	case types.String, types.Bool:
+		return v.Interface(), nil
+	case types.Int, types.Uint:
+		return u.convertNumber(elem, v)
+	case types.Address:
+		return u.convertAddress(v)
+	case types.Bytes:
+		return u.convertBytes(v)
+	case types.Array:
+		return u.convertArray(elem, v)
+	case types.Object:
+		return u.convertObject(elem, v)
+	}
+
+	return nil, fmt.Errorf("unwelder does not support %q", elem.Type)
+}
+
+// convertNumber renders an integer according to the IntegerFormat
+func (u *Unwelder) convertNumber(elem types.Element, v reflect.Value) (any, error) {
+	var number *big.Int
+	switch {
+	case v.Type() == bigIntTy:
+		number = v.Interface().(*big.Int)
+	case v.CanInt():
+		number = big.NewInt(v.Int())
+	case v.CanUint():
+		number = new(big.Int).SetUint64(v.Uint())
+	default:
+		return nil, fmt.Errorf("expected integer, got %s", v.Type())
+	}
+
+	size := elem.Size
+	if size == 0 {
+		size = 64
+	}
+
+	switch {
+	case u.IntegerFormat == StringIntegers:
+		return number.String(), nil
+	case u.IntegerFormat == SafeIntegers && size > maxSafeIntegerBits:
+		return number.String(), nil
+	}
+
+	return json.Number(number.String()), nil
+}
+
+// convertAddress renders an address in its EIP-55 checksum form
+func (u *Unwelder) convertAddress(v reflect.Value) (any, error) {
+	if v.Type() != reflect.TypeOf(common.Address{}) {
+		return nil, fmt.Errorf("expected address, got %s", v.Type())
+	}
+
+	return v.Interface().(common.Address).Hex(), nil
+}
+
+// convertBytes renders dynamic and fixed-size bytes as 0x-prefixed hex
+func (u *Unwelder) convertBytes(v reflect.Value) (any, error) {
+	if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, fmt.Errorf("expected bytes, got %s", v.Type())
+	}
+
+	buf := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(buf), v)
+	return hexutil.Encode(buf), nil
+}
+
+// convertArray converts every item of a slice or array with the array's child element
+func (u *Unwelder) convertArray(elem types.Element, v reflect.Value) (any, error) {
+	if len(elem.Children) != 1 {
+		return nil, fmt.Errorf("array must have one child")
+	}
+
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected array, got %s", v.Type())
+	}
+
+	result := make([]any, v.Len())
+	for i := range result {
+		item, err := u.convert(elem.Children[0], v.Index(i))
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		result[i] = item
+	}
+
+	return result, nil
+}
+
+// convertObject converts the fields of a struct into an object keyed by the schema names
+// The struct fields are expected in the same order as the element's children
+func (u *Unwelder) convertObject(elem types.Element, v reflect.Value) (any, error) {
+	if v.Kind() != reflect.Struct || v.NumField() != len(elem.Children) {
+		return nil, fmt.Errorf("expected struct with %d fields, got %s", len(elem.Children), v.Type())
+	}
+
+	result := make(utils.OrderedObject, len(elem.Children))
+	for i, child := range elem.Children {
+		field, err := u.convert(child, v.Field(i))
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", child.Name, err)
+		}
+		result[i] = utils.OrderedField{Key: child.Name, Value: field}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,103 @@
+package ether
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnwelder_Marshal(t *testing.T) {
+	type Balance struct {
+		Amount   *big.Int
+		Currency string
+	}
+
+	type Account struct {
+		Owner   common.Address
+		Balance Balance
+		Tag     [4]byte
+	}
+
+	type Testcase struct {
+		Name     string
+		Format   IntegerFormat
+		Schema   types.Elements
+		Input    []any
+		Expected string
+	}
+
+	large, _ := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
+	account := Account{
+		Owner:   common.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"),
+		Balance: Balance{Amount: big.NewInt(1000), Currency: "ETH"},
+		Tag:     [4]byte{1, 2, 3, 4},
+	}
+	accountSchema := types.Element{Type: types.Object, Name: "account", Children: types.Elements{
+		{Type: types.Address, Name: "owner"},
+		{Type: types.Object, Name: "balance", Children: types.Elements{{Type: types.Uint, Size: 256, Name: "amount"}, {Type: types.String, Name: "currency"}}},
+		{Type: types.Bytes, Size: 4, Name: "tag"},
+	}}
+
+	testcases := []Testcase{
+		{
+			Name:     "safe-integers",
+			Schema:   types.Elements{{Type: types.Uint, Size: 8}, {Type: types.Int, Size: 32}, {Type: types.Uint}, {Type: types.Uint, Size: 256}},
+			Input:    []any{uint8(255), int32(-7), uint64(1 << 60), large},
+			Expected: `[255,-7,"1152921504606846976","115792089237316195423570985008687907853269984665640564039457584007913129639935"]`,
+		},
+		{
+			Name:     "string-integers",
+			Format:   StringIntegers,
+			Schema:   types.Elements{{Type: types.Uint, Size: 8}, {Type: types.Int, Size: 256}},
+			Input:    []any{uint8(1), big.NewInt(-1)},
+			Expected: `["1","-1"]`,
+		},
+		{
+			Name:     "number-integers",
+			Format:   NumberIntegers,
+			Schema:   types.Elements{{Type: types.Uint, Size: 256}},
+			Input:    []any{large},
+			Expected: `[115792089237316195423570985008687907853269984665640564039457584007913129639935]`,
+		},
+		{
+			Name:     "nested-object",
+			Schema:   types.Elements{{Type: types.String}, accountSchema},
+			Input:    []any{"hello", account},
+			Expected: `["hello",{"owner":"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266","balance":{"amount":"1000","currency":"ETH"},"tag":"0x01020304"}]`,
+		},
+		{
+			Name: "arrays-and-bytes",
+			Schema: types.Elements{
+				{Type: types.Array, Children: types.Elements{{Type: types.Bool}}},
+				{Type: types.Array, Size: 2, Children: types.Elements{{Type: types.Bytes}}},
+			},
+			Input:    []any{[]bool{true, false}, [2][]byte{{0xde, 0xad}, {}}},
+			Expected: `[[true,false],["0xdead","0x"]]`,
+		},
+		{
+			Name:     "optional-pointers",
+			Schema:   types.Elements{{Type: types.String, Optional: true}, {Type: types.Uint, Size: 16, Optional: true}},
+			Input:    []any{(*string)(nil), &[]uint16{7}[0]},
+			Expected: `[null,7]`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := NewUnwelder(tc.Format).Marshal(tc.Schema, tc.Input)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.Expected, string(actual))
+		})
+	}
+
+	t.Run("mismatch", func(t *testing.T) {
+		_, err := NewUnwelder(SafeIntegers).Marshal(types.Elements{{Type: types.Address}}, []any{"0x00"})
+		assert.Error(t, err)
+
+		_, err = NewUnwelder(SafeIntegers).Marshal(types.Elements{{Type: types.String}}, []any{"a", "b"})
+		assert.Error(t, err)
+	})
+}
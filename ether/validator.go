@@ -0,0 +1,306 @@
+package ether
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+// ValidationCode is a machine-readable identifier of a validation failure
+type ValidationCode string
+
+const (
+	// CodeInvalidJSON reports that the payload is not valid JSON
+	CodeInvalidJSON = ValidationCode("invalid_json")
+	// CodeInvalidSchema reports that the schema element itself cannot be welded
+	CodeInvalidSchema = ValidationCode("invalid_schema")
+	// CodeRequired reports that a value required by the schema is missing or null
+	CodeRequired = ValidationCode("required")
+	// CodeTypeMismatch reports that the JSON kind of a value doesn't match the element type
+	CodeTypeMismatch = ValidationCode("type_mismatch")
+	// CodeInvalidFormat reports that a value has the right JSON kind but a malformed content
+	CodeInvalidFormat = ValidationCode("invalid_format")
+	// CodeOutOfRange reports that an integer doesn't fit the element size and signedness
+	CodeOutOfRange = ValidationCode("out_of_range")
+	// CodeInvalidLength reports that a fixed-size array or bytes value has the wrong length
+	CodeInvalidLength = ValidationCode("invalid_length")
+	// CodeUnknownField reports that a top-level value is not part of the schema
+	CodeUnknownField = ValidationCode("unknown_field")
+	// CodeUnsupportedType reports that the element type cannot be represented on the EVM
+	CodeUnsupportedType = ValidationCode("unsupported_type")
+)
+
+// ValidationError describes a single value of the payload that doesn't match the schema
+type ValidationError struct {
+	// Path is the JSON pointer of the offending value, e.g. `/1/balance/amount`
+	Path string `json:"path"`
+	// Code is the machine-readable reason of the failure
+	Code ValidationCode `json:"code"`
+	// Expected is the type declared by the schema
+	Expected types.ElementType `json:"expected,omitempty"`
+	// Size is the size declared by the schema
+	Size int `json:"size,omitempty"`
+	// Value is the offending value, nil if it is missing
+	Value any `json:"value,omitempty"`
+	// Message is a human-readable description of the failure
+	Message string `json:"message"`
+}
+
+// Error implements the error interface
+func (e ValidationError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return fmt.Sprintf("%s: %s", path, e.Message)
+}
+
+// Validator checks JSON payloads against a schema before welding
+// Unlike the Cleanser it never coerces values, it reports them instead
+type Validator struct{}
+
+// NewValidator creates a new instance of Validator
+func NewValidator() *Validator { return &Validator{} }
+
+// Validate checks the JSON payload against the schema
+// Returns every mismatch found, or an empty slice if the payload can be welded
+func Validate(schema types.Elements, data []byte) []ValidationError {
+	return NewValidator().Validate(schema, data)
+}
+
+// Validate checks the JSON payload against the schema
+// Returns every mismatch found, or an empty slice if the payload can be welded
+func (v *Validator) Validate(schema types.Elements, data []byte) []ValidationError {
+	errs := make([]ValidationError, 0)
+
+	root, err := utils.DecodeJSON(data)
+	if err != nil {
+		return append(errs, ValidationError{Code: CodeInvalidJSON, Message: err.Error()})
+	}
+
+	items, ok := root.([]any)
+	if !ok {
+		return append(errs, ValidationError{Code: CodeTypeMismatch, Value: root, Message: fmt.Sprintf("payload must be an array, got %s", jsonKind(root))})
+	}
+
+	for i, elem := range schema {
+		path := utils.JSONPointer("", i)
+		if i >= len(items) {
+			v.validate(elem, nil, path, &errs)
+			continue
+		}
+		v.validate(elem, items[i], path, &errs)
+	}
+
+	for i := len(schema); i < len(items); i++ {
+		errs = append(errs, ValidationError{Path: utils.JSONPointer("", i), Code: CodeUnknownField, Value: items[i], Message: fmt.Sprintf("schema only declares %d values", len(schema))})
+	}
+
+	return errs
+}
+
+// validate checks a single value according to its element
+// Dispatches to the appropriate type-specific validator based on the element type
+func (v *Validator) validate(elem types.Element, value any, path string, errs *[]ValidationError) {
+	if value == nil && elem.Default != nil {
+		def, err := utils.DecodeJSON(elem.Default)
+		if err != nil {
+			*errs = append(*errs, failure(elem, path, CodeInvalidSchema, nil, "invalid default: %v", err))
+			return
+		}
+		value = def
+	}
+
+	if value == nil {
+		if !elem.Optional {
+			*errs = append(*errs, missing(elem, path))
+		}
+		return
+	}
+
+	switch elem.Type {
+	case types.String:
+		v.validateString(elem, value, path, errs)
+	case types.Int, types.Uint:
+		v.validateNumber(elem, value, path, errs)
+	case types.Bool:
+		v.validateBool(elem, value, path, errs)
+	case types.Address:
+		v.validateAddress(elem, value, path, errs)
+	case types.Bytes:
+		v.validateBytes(elem, value, path, errs)
+	case types.Array:
+		v.validateArray(elem, value, path, errs)
+	case types.Object:
+		v.validateObject(elem, value, path, errs)
+	default:
+		*errs = append(*errs, failure(elem, path, CodeUnsupportedType, value, "type %q is not supported by the EVM", elem.Type))
+	}
+}
+
+// validateString checks that the value is a JSON string
+func (v *Validator) validateString(elem types.Element, value any, path string, errs *[]ValidationError) {
+	if _, ok := value.(string); !ok {
+		*errs = append(*errs, mismatch(elem, path, value))
+	}
+}
+
+// validateNumber checks that the value is an integer that fits the element size and signedness
+// Integers are parsed by utils.ParseInteger, the same way Weld and the encoders parse them
+func (v *Validator) validateNumber(elem types.Element, value any, path string, errs *[]ValidationError) {
+	if _, err := utils.ParseInteger(elem, value); err != nil {
+		*errs = append(*errs, integerFailure(elem, path, value, err))
+	}
+}
+
+// validateBool checks that the value is a JSON boolean
+func (v *Validator) validateBool(elem types.Element, value any, path string, errs *[]ValidationError) {
+	if _, ok := value.(bool); !ok {
+		*errs = append(*errs, mismatch(elem, path, value))
+	}
+}
+
+// validateAddress checks that the value is a 0x-prefixed 20-byte hex string
+func (v *Validator) validateAddress(elem types.Element, value any, path string, errs *[]ValidationError) {
+	s, ok := value.(string)
+	if !ok {
+		*errs = append(*errs, mismatch(elem, path, value))
+		return
+	}
+
+	if !strings.HasPrefix(s, "0x") || len(s) != 42 || !isHex(s[2:]) {
+		*errs = append(*errs, failure(elem, path, CodeInvalidFormat, value, "expected a 0x-prefixed 20-byte hex address"))
+	}
+}
+
+// validateBytes checks that the value is 0x-prefixed hex, or a byte array for fixed-size bytes
+// Fixed-size bytes must match the element size exactly
+func (v *Validator) validateBytes(elem types.Element, value any, path string, errs *[]ValidationError) {
+	if elem.Size < 0 || elem.Size > 32 {
+		*errs = append(*errs, failure(elem, path, CodeInvalidSchema, nil, "invalid bytes size %d", elem.Size))
+		return
+	}
+
+	length := 0
+	switch val := value.(type) {
+	case string:
+		if !strings.HasPrefix(val, "0x") || len(val)%2 != 0 || !isHex(val[2:]) {
+			*errs = append(*errs, failure(elem, path, CodeInvalidFormat, value, "expected 0x-prefixed hex with an even number of digits"))
+			return
+		}
+		length = len(val)/2 - 1
+	case []any:
+		if elem.Size == 0 {
+			*errs = append(*errs, mismatch(elem, path, value))
+			return
+		}
+
+		for i, item := range val {
+			n, ok := item.(json.Number)
+			if b, valid := new(big.Int).SetString(n.String(), 10); !ok || !valid || b.Sign() < 0 || b.BitLen() > 8 {
+				*errs = append(*errs, failure(elem, utils.JSONPointer(path, i), CodeInvalidFormat, item, "expected a byte between 0 and 255"))
+				return
+			}
+		}
+		length = len(val)
+	default:
+		*errs = append(*errs, mismatch(elem, path, value))
+		return
+	}
+
+	if elem.Size > 0 && length != elem.Size {
+		*errs = append(*errs, failure(elem, path, CodeInvalidLength, value, "expected %d bytes, got %d", elem.Size, length))
+	}
+}
+
+// validateArray checks every item of the array and the length of fixed-size arrays
+func (v *Validator) validateArray(elem types.Element, value any, path string, errs *[]ValidationError) {
+	if len(elem.Children) != 1 {
+		*errs = append(*errs, failure(elem, path, CodeInvalidSchema, nil, "array must have one child"))
+		return
+	}
+
+	items, ok := value.([]any)
+	if !ok {
+		*errs = append(*errs, mismatch(elem, path, value))
+		return
+	}
+
+	// Missing slots of fixed-size arrays are filled with the child default when welding
+	if elem.Size > 0 && len(items) < elem.Size && elem.Children[0].Default != nil {
+		items = append(items, make([]any, elem.Size-len(items))...)
+	}
+
+	if elem.Size > 0 && len(items) != elem.Size {
+		*errs = append(*errs, failure(elem, path, CodeInvalidLength, nil, "expected %d items, got %d", elem.Size, len(items)))
+	}
+
+	for i, item := range items {
+		v.validate(elem.Children[0], item, utils.JSONPointer(path, i), errs)
+	}
+}
+
+// validateObject checks every field of the object
+// Keys unknown to the schema are not reported since welding ignores them
+func (v *Validator) validateObject(elem types.Element, value any, path string, errs *[]ValidationError) {
+	if len(elem.Children) == 0 {
+		*errs = append(*errs, failure(elem, path, CodeInvalidSchema, nil, "object must have at least one child"))
+		return
+	}
+
+	fields, ok := value.(map[string]any)
+	if !ok {
+		*errs = append(*errs, mismatch(elem, path, value))
+		return
+	}
+
+	for _, child := range elem.Children {
+		v.validate(child, fields[child.Name], utils.JSONPointer(path, child.Name), errs)
+	}
+}
+
+// failure creates a ValidationError for the element at the given path
+func failure(elem types.Element, path string, code ValidationCode, value any, format string, args ...any) ValidationError {
+	return ValidationError{
+		Path:     path,
+		Code:     code,
+		Expected: elem.Type,
+		Size:     elem.Size,
+		Value:    value,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// mismatch creates a ValidationError for a value whose JSON kind doesn't match the element
+func mismatch(elem types.Element, path string, value any) ValidationError {
+	return failure(elem, path, CodeTypeMismatch, value, "expected %s, got %s", elem.Type, jsonKind(value))
+}
+
+// integerFailure creates a ValidationError for an integer rejected by utils.ParseInteger or utils.CheckInteger
+func integerFailure(elem types.Element, path string, value any, err error) ValidationError {
+	var ierr *utils.IntegerError
+	if !errors.As(err, &ierr) {
+		return failure(elem, path, CodeInvalidSchema, nil, "%v", err)
+	}
+
+	switch ierr.Code {
+	case utils.IntegerMismatch:
+		return mismatch(elem, path, value)
+	case utils.IntegerInvalidFormat:
+		return failure(elem, path, CodeInvalidFormat, value, "%v", ierr)
+	case utils.IntegerOutOfRange:
+		return failure(elem, path, CodeOutOfRange, value, "%v", ierr)
+	}
+	return failure(elem, path, CodeInvalidSchema, nil, "%v", ierr)
+}
+
+// missing creates a ValidationError for a required value that is absent
+func missing(elem types.Element, path string) ValidationError {
+	return failure(elem, path, CodeRequired, nil, "value is required")
+}
@@ -0,0 +1,109 @@
+package ether
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	schema := types.Elements{
+		{Type: types.String},
+		{
+			Type: types.Object,
+			Children: types.Elements{
+				{Type: types.Address, Name: "owner"},
+				{Type: types.Object, Name: "balance", Children: types.Elements{
+					{Type: types.Uint, Size: 8, Name: "amount"},
+					{Type: types.Bytes, Size: 4, Name: "tag"},
+				}},
+				{Type: types.Array, Size: 2, Name: "flags", Children: types.Elements{{Type: types.Bool}}},
+			},
+		},
+	}
+
+	type Testcase struct {
+		Name     string
+		Input    string
+		Expected []ValidationError
+	}
+
+	testcases := []Testcase{
+		{
+			Name:     "valid",
+			Input:    `["hello", {"owner": "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "balance": {"amount": 255, "tag": "0x01020304"}, "flags": [true, false]}]`,
+			Expected: []ValidationError{},
+		},
+		{
+			Name:     "unknown-keys",
+			Input:    `["hello", {"owner": "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "balance": {"amount": 1, "tag": "0x01020304", "note": "x"}, "flags": [true, false], "extra": {"a": 1}}]`,
+			Expected: []ValidationError{},
+		},
+		{
+			Name:  "invalid-json",
+			Input: `["hello",`,
+			Expected: []ValidationError{
+				{Code: CodeInvalidJSON, Message: "unexpected EOF"},
+			},
+		},
+		{
+			Name:  "nested-errors",
+			Input: `[1, {"owner": "0x1234", "balance": {"amount": 300, "tag": "0x0102"}, "flags": [true], "extra": 1}, null]`,
+			Expected: []ValidationError{
+				{Path: "/0", Code: CodeTypeMismatch, Expected: types.String, Value: json.Number("1"), Message: "expected string, got number"},
+				{Path: "/1/owner", Code: CodeInvalidFormat, Expected: types.Address, Value: "0x1234", Message: "expected a 0x-prefixed 20-byte hex address"},
+				{Path: "/1/balance/amount", Code: CodeOutOfRange, Expected: types.Uint, Size: 8, Value: json.Number("300"), Message: "value must be between 0 and 255"},
+				{Path: "/1/balance/tag", Code: CodeInvalidLength, Expected: types.Bytes, Size: 4, Value: "0x0102", Message: "expected 4 bytes, got 2"},
+				{Path: "/1/flags", Code: CodeInvalidLength, Expected: types.Array, Size: 2, Message: "expected 2 items, got 1"},
+				{Path: "/2", Code: CodeUnknownField, Message: "schema only declares 2 values"},
+			},
+		},
+		{
+			Name:  "missing-values",
+			Input: `["hello", {"balance": {"amount": 1.5}}]`,
+			Expected: []ValidationError{
+				{Path: "/1/owner", Code: CodeRequired, Expected: types.Address, Message: "value is required"},
+				{Path: "/1/balance/amount", Code: CodeInvalidFormat, Expected: types.Uint, Size: 8, Value: json.Number("1.5"), Message: "expected an integer without fraction or exponent"},
+				{Path: "/1/balance/tag", Code: CodeRequired, Expected: types.Bytes, Size: 4, Message: "value is required"},
+				{Path: "/1/flags", Code: CodeRequired, Expected: types.Array, Size: 2, Message: "value is required"},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, Validate(schema, []byte(tc.Input)))
+		})
+	}
+}
+
+func TestValidate_IntegerBounds(t *testing.T) {
+	type Testcase struct {
+		Name  string
+		Elem  types.Element
+		Input string
+		Valid bool
+	}
+
+	testcases := []Testcase{
+		{Name: "int8-min", Elem: types.Element{Type: types.Int, Size: 8}, Input: `[-128]`, Valid: true},
+		{Name: "int8-underflow", Elem: types.Element{Type: types.Int, Size: 8}, Input: `[-129]`, Valid: false},
+		{Name: "uint-negative", Elem: types.Element{Type: types.Uint}, Input: `[-1]`, Valid: false},
+		{Name: "uint64-default-size", Elem: types.Element{Type: types.Uint}, Input: `[18446744073709551615]`, Valid: true},
+		{Name: "uint160-overflow", Elem: types.Element{Type: types.Uint, Size: 160}, Input: `[1461501637330902918203684832716283019655932542976]`, Valid: false},
+		{Name: "uint256-max", Elem: types.Element{Type: types.Uint, Size: 256}, Input: `[115792089237316195423570985008687907853269984665640564039457584007913129639935]`, Valid: true},
+		{Name: "uint256-decimal-string", Elem: types.Element{Type: types.Uint, Size: 256}, Input: `["115792089237316195423570985008687907853269984665640564039457584007913129639935"]`, Valid: true},
+		{Name: "uint8-string-overflow", Elem: types.Element{Type: types.Uint, Size: 8}, Input: `["256"]`, Valid: false},
+		{Name: "hex-string", Elem: types.Element{Type: types.Uint, Size: 8}, Input: `["0x01"]`, Valid: false},
+		{Name: "invalid-size", Elem: types.Element{Type: types.Uint, Size: 12}, Input: `[1]`, Valid: false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			errs := Validate(types.Elements{tc.Elem}, []byte(tc.Input))
+			assert.Equal(t, tc.Valid, len(errs) == 0, errs)
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package welder_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ideatru/welder"
+	"github.com/ideatru/welder/ether"
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEthereumWelder_Weld(t *testing.T) {
+	schema := types.Elements{
+		{Type: types.String},
+		{
+			Type: types.Object,
+			Children: types.Elements{
+				{Type: types.Address, Name: "owner"},
+				{Type: types.String, Name: "name"},
+				{Type: types.Object, Name: "balance", Children: types.Elements{
+					{Type: types.Uint, Size: 256, Name: "amount"},
+					{Type: types.String, Name: "currency"},
+				}},
+				{Type: types.Bytes, Size: 4, Name: "tag"},
+			},
+		},
+	}
+	payload := []byte(`["Hello, World!!!",{"owner": "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "name": "Ether", "balance": {"amount": 1000000000000000000, "currency": "ETH"}, "tag": "0x01020304"}]`)
+
+	w := welder.NewEthereum()
+	args, err := w.Serialize(schema)
+	assert.NoError(t, err)
+
+	params, err := w.Weld(schema, payload)
+	assert.NoError(t, err)
+
+	data, err := args.Encode(params...)
+	assert.NoError(t, err)
+
+	decoded, err := args.Decode(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, World!!!", decoded[0])
+}
+
+func TestEthereumWelder_WeldIntegerRange(t *testing.T) {
+	type Testcase struct {
+		Name    string
+		Elem    types.Element
+		Value   string
+		Invalid bool
+	}
+
+	testcases := make([]Testcase, 0)
+	for size := 8; size <= 256; size += 8 {
+		max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(size)), big.NewInt(1))
+		half := new(big.Int).Lsh(big.NewInt(1), uint(size-1))
+		testcases = append(testcases,
+			Testcase{Name: fmt.Sprintf("uint%d-max", size), Elem: types.Element{Name: "value", Type: types.Uint, Size: size}, Value: max.String()},
+			Testcase{Name: fmt.Sprintf("uint%d-overflow", size), Elem: types.Element{Name: "value", Type: types.Uint, Size: size}, Value: new(big.Int).Add(max, big.NewInt(1)).String(), Invalid: true},
+			Testcase{Name: fmt.Sprintf("uint%d-negative", size), Elem: types.Element{Name: "value", Type: types.Uint, Size: size}, Value: "-1", Invalid: true},
+			Testcase{Name: fmt.Sprintf("int%d-min", size), Elem: types.Element{Name: "value", Type: types.Int, Size: size}, Value: new(big.Int).Neg(half).String()},
+			Testcase{Name: fmt.Sprintf("int%d-underflow", size), Elem: types.Element{Name: "value", Type: types.Int, Size: size}, Value: new(big.Int).Sub(new(big.Int).Neg(half), big.NewInt(1)).String(), Invalid: true},
+			Testcase{Name: fmt.Sprintf("int%d-overflow", size), Elem: types.Element{Name: "value", Type: types.Int, Size: size}, Value: half.String(), Invalid: true},
+		)
+	}
+
+	w := welder.NewEthereum()
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			schema := types.Elements{{Type: types.Object, Children: types.Elements{tc.Elem}}}
+			params, err := w.Weld(schema, []byte(`[{"value": `+tc.Value+`}]`))
+			if tc.Invalid {
+				var verr ether.ValidationError
+				assert.ErrorAs(t, err, &verr)
+				assert.Equal(t, ether.CodeOutOfRange, verr.Code)
+				assert.Equal(t, "/0/value", verr.Path)
+				assert.Contains(t, verr.Message, `"value"`)
+				return
+			}
+
+			assert.NoError(t, err)
+			args, err := w.Serialize(schema)
+			assert.NoError(t, err)
+
+			_, err = args.Encode(params...)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEthereumWelder_WeldIntegerStrings(t *testing.T) {
+	schema := types.Elements{{Type: types.Uint, Size: 256}, {Type: types.Int, Size: 128}}
+
+	type Testcase struct {
+		Name  string
+		Input string
+		Code  ether.ValidationCode
+	}
+
+	testcases := []Testcase{
+		{Name: "decimal-strings", Input: `["115792089237316195423570985008687907853269984665640564039457584007913129639935", "-42"]`},
+		{Name: "mixed", Input: `[5, "-42"]`},
+		{Name: "hex-string", Input: `["0x10", 1]`, Code: ether.CodeInvalidFormat},
+		{Name: "fraction-string", Input: `["1.5", 1]`, Code: ether.CodeInvalidFormat},
+		{Name: "negative-uint", Input: `["-1", 1]`, Code: ether.CodeOutOfRange},
+	}
+
+	w := welder.NewEthereum()
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := w.Weld(schema, []byte(tc.Input))
+			if tc.Code != "" {
+				var verr ether.ValidationError
+				assert.ErrorAs(t, err, &verr)
+				assert.Equal(t, tc.Code, verr.Code)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Empty(t, w.Validate(schema, []byte(tc.Input)))
+		})
+	}
+}
+
+func TestEthereumWelder_WeldFixedBytes(t *testing.T) {
+	schema := types.Elements{{Type: types.Bytes, Size: 2}, {Type: types.Bytes, Size: 2}}
+
+	w := welder.NewEthereum()
+	params, err := w.Weld(schema, []byte(`["0x0102", [3, 4]]`))
+	assert.NoError(t, err)
+
+	args, err := w.Serialize(schema)
+	assert.NoError(t, err)
+
+	data, err := args.Encode(params...)
+	assert.NoError(t, err)
+	assert.Equal(t, hexutil.MustDecode("0x01020000000000000000000000000000000000000000000000000000000000000304000000000000000000000000000000000000000000000000000000000000"), data)
+
+	_, err = w.Weld(schema, []byte(`["0x010203", [3, 4]]`))
+	assert.Error(t, err)
+}
+
+func TestEthereumWelder_WeldOptional(t *testing.T) {
+	schema := types.Elements{
+		{Type: types.String},
+		{Type: types.Object, Children: types.Elements{
+			{Type: types.Address, Name: "owner"},
+			{Type: types.Uint, Size: 256, Name: "deadline", Optional: true},
+			{Type: types.String, Name: "memo", Optional: true},
+		}},
+		{Type: types.Bool, Optional: true},
+	}
+
+	t.Run("reject-by-default", func(t *testing.T) {
+		_, err := welder.NewEthereum().Serialize(schema)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing-required", func(t *testing.T) {
+		_, err := welder.NewEthereum().Weld(schema, []byte(`["hello", {"deadline": 1}]`))
+
+		var verr ether.ValidationError
+		assert.ErrorAs(t, err, &verr)
+		assert.Equal(t, ether.CodeRequired, verr.Code)
+		assert.Equal(t, "/1/owner", verr.Path)
+	})
+
+	t.Run("zero-policy", func(t *testing.T) {
+		w := welder.NewEthereum(welder.Option{OptionalPolicy: ether.ZeroOptional})
+
+		args, err := w.Serialize(schema)
+		assert.NoError(t, err)
+
+		missing, err := w.Weld(schema, []byte(`["hello", {"owner": "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "memo": null}]`))
+		assert.NoError(t, err)
+
+		zeroed, err := w.Weld(schema, []byte(`["hello", {"owner": "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "deadline": 0, "memo": ""}, false]`))
+		assert.NoError(t, err)
+
+		actual, err := args.Encode(missing...)
+		assert.NoError(t, err)
+
+		expected, err := args.Encode(zeroed...)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+}
+
+func TestEthereumWelder_WeldDefault(t *testing.T) {
+	schema := types.Elements{
+		{Type: types.Object, Children: types.Elements{
+			{Type: types.Uint, Size: 256, Name: "amount"},
+			{Type: types.Uint, Size: 256, Name: "deadline", Default: json.RawMessage(`0`)},
+			{Type: types.Address, Name: "referrer", Default: json.RawMessage(`"0x0000000000000000000000000000000000000000"`)},
+		}},
+		{Type: types.Array, Size: 3, Children: types.Elements{{Type: types.Uint, Size: 8, Default: json.RawMessage(`7`)}}},
+		{Type: types.String, Default: json.RawMessage(`"v1"`)},
+	}
+
+	w := welder.NewEthereum()
+	args, err := w.Serialize(schema)
+	assert.NoError(t, err)
+
+	defaulted, err := w.Weld(schema, []byte(`[{"amount": 1}, [1]]`))
+	assert.NoError(t, err)
+
+	explicit, err := w.Weld(schema, []byte(`[{"amount": 1, "deadline": 0, "referrer": "0x0000000000000000000000000000000000000000"}, [1, 7, 7], "v1"]`))
+	assert.NoError(t, err)
+
+	actual, err := args.Encode(defaulted...)
+	assert.NoError(t, err)
+
+	expected, err := args.Encode(explicit...)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	_, err = w.Weld(schema, []byte(`[{"amount": 1}, [1, 2, 3, 4]]`))
+	assert.Error(t, err)
+
+	assert.Empty(t, w.Validate(schema, []byte(`[{"amount": 1}, [1]]`)))
+}
+
+func TestEthereumWelder_ValidateMatchesWeld(t *testing.T) {
+	schema := types.Elements{
+		{Type: types.Object, Children: types.Elements{{Name: "amount", Type: types.Uint, Size: 8}}},
+		{Type: types.String, Optional: true},
+	}
+
+	type Testcase struct {
+		Name    string
+		Input   string
+		Invalid bool
+	}
+
+	testcases := []Testcase{
+		{Name: "complete", Input: `[{"amount": 1}, "a"]`},
+		{Name: "unknown-keys", Input: `[{"amount": 1, "note": "x", "nested": {"a": [1]}}, "a"]`},
+		{Name: "missing-optional-value", Input: `[{"amount": 1}]`},
+		{Name: "extra-value", Input: `[{"amount": 1}, "a", true]`, Invalid: true},
+		{Name: "missing-required-value", Input: `[]`, Invalid: true},
+	}
+
+	w := welder.NewEthereum(welder.Option{OptionalPolicy: ether.ZeroOptional})
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := w.Weld(schema, []byte(tc.Input))
+			errs := w.Validate(schema, []byte(tc.Input))
+			if tc.Invalid {
+				assert.Error(t, err)
+				assert.NotEmpty(t, errs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Empty(t, errs)
+		})
+	}
+}
+
+func TestEthereumWelder_Unweld(t *testing.T) {
+	schema := types.Elements{
+		{Type: types.String},
+		{Type: types.Object, Children: types.Elements{
+			{Type: types.Address, Name: "owner"},
+			{Type: types.Object, Name: "balance", Children: types.Elements{
+				{Type: types.Uint, Size: 256, Name: "amount"},
+				{Type: types.String, Name: "currency"},
+			}},
+			{Type: types.Bytes, Size: 4, Name: "tag"},
+		}},
+		{Type: types.Array, Size: 2, Children: types.Elements{{Type: types.Int, Size: 16}}},
+		{Type: types.Bytes},
+	}
+	payload := `["Hello",{"owner":"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266","balance":{"amount":"1000000000000000000","currency":"ETH"},"tag":"0x01020304"},[-1,2],"0xdeadbeef"]`
+
+	w := welder.NewEthereum()
+	args, err := w.Serialize(schema)
+	assert.NoError(t, err)
+
+	params, err := w.Weld(schema, []byte(strings.Replace(payload, `"1000000000000000000"`, `1000000000000000000`, 1)))
+	assert.NoError(t, err)
+
+	data, err := args.Encode(params...)
+	assert.NoError(t, err)
+
+	actual, err := w.Unweld(schema, data)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, string(actual))
+
+	numbers, err := welder.NewEthereum(welder.Option{IntegerFormat: ether.NumberIntegers}).Unweld(schema, data)
+	assert.NoError(t, err)
+	assert.Contains(t, string(numbers), `"amount":1000000000000000000`)
+
+	_, err = w.Unweld(schema, data[:32])
+	assert.Error(t, err)
+}
+
+func TestEthereumWelder_ExampleJSON(t *testing.T) {
+	schema := types.Elements{
+		{Type: types.String},
+		{Type: types.Object, Children: types.Elements{
+			{Type: types.Address, Name: "owner"},
+			{Type: types.Array, Size: 2, Name: "limits", Children: types.Elements{{Type: types.Int, Size: 128}}},
+			{Type: types.Array, Name: "tags", Children: types.Elements{{Type: types.Bytes, Size: 32}}},
+			{Type: types.Object, Name: "balance", Children: types.Elements{
+				{Type: types.Uint, Size: 256, Name: "amount"},
+				{Type: types.String, Name: "currency"},
+			}},
+		}},
+		{Type: types.Bytes},
+	}
+
+	w := welder.NewEthereum()
+	payload, err := w.ExampleJSON(schema)
+	assert.NoError(t, err)
+
+	again, err := w.ExampleJSON(schema)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, again)
+
+	params, err := w.Weld(schema, payload)
+	assert.NoError(t, err)
+
+	args, err := w.Serialize(schema)
+	assert.NoError(t, err)
+
+	data, err := args.Encode(params...)
+	assert.NoError(t, err)
+
+	actual, err := welder.NewEthereum(welder.Option{IntegerFormat: ether.NumberIntegers}).Unweld(schema, data)
+	assert.NoError(t, err)
+	assert.Equal(t, string(payload), string(actual))
+}
@@ -98,10 +98,49 @@ func (b *Builder) Build(elem types.Element) (value any, err error) {
 	return
 }
 
+// BuildTypes constructs the types defined by the provided elements without instantiating them
+// Types are immutable, so that they can be built once and instantiated with reflect.New for every payload
+// Recovers from panics that might occur during reflection operations
+func (b *Builder) BuildTypes(elements types.Elements) (tys []reflect.Type, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+			tys = nil
+			return
+		}
+	}()
+
+	tys = make([]reflect.Type, len(elements))
+	for i, elem := range elements {
+		tys[i], err = b.buildType(elem)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tys, nil
+}
+
 // buildType creates a reflect.Type based on the provided element
-// Dispatches to the appropriate type builder based on the element's type
+// Optional elements are wrapped into a pointer so that a missing value stays nil
 // Returns the reflect.Type or an error if type building fails
 func (b *Builder) buildType(elem types.Element) (reflect.Type, error) {
+	ty, err := b.buildElementType(elem)
+	if err != nil {
+		return nil, err
+	}
+
+	if elem.Optional {
+		return reflect.PointerTo(ty), nil
+	}
+
+	return ty, nil
+}
+
+// buildElementType creates a reflect.Type based on the provided element
+// Dispatches to the appropriate type builder based on the element's type
+// Returns the reflect.Type or an error if type building fails
+func (b *Builder) buildElementType(elem types.Element) (reflect.Type, error) {
 	switch elem.Type {
 	case types.String:
 		return unwrapReplacer(elem, b.buildString, b.ReflectReplacers)
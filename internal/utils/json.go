@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// OrderedField is a single key/value pair of an OrderedObject
+type OrderedField struct {
+	Key   string
+	Value any
+}
+
+// OrderedObject is a JSON object that preserves the order of its keys when marshalled
+// Used to emit objects in schema order instead of the sorted order of map[string]any
+type OrderedObject []OrderedField
+
+// MarshalJSON encodes the object keeping the insertion order of its fields
+func (o OrderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// DecodeJSON decodes a single JSON document into generic Go values
+// Numbers are kept as json.Number so that big integers don't lose precision
+// Returns an error if the document is invalid or followed by trailing data
+func DecodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid character after top-level value")
+	}
+
+	return value, nil
+}
+
+// pointerEscaper escapes the reference tokens of JSON pointers
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// JSONPointer appends a reference token to a JSON pointer (RFC 6901)
+// The token is escaped so that `~` and `/` inside object keys stay addressable
+func JSONPointer(parent string, token any) string {
+	switch t := token.(type) {
+	case int:
+		return parent + "/" + strconv.Itoa(t)
+	case string:
+		return parent + "/" + pointerEscaper.Replace(t)
+	}
+
+	return parent + "/" + fmt.Sprint(token)
+}
@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ideatru/welder/types"
+)
+
+// IntegerBounds returns the inclusive range of values representable by a numeric element
+// A size of 0 is treated as 64 bits, the same way the builder and the Ethereum parser do
+// Returns an error if the size is not a multiple of 8 between 8 and 256
+func IntegerBounds(elem types.Element) (*big.Int, *big.Int, error) {
+	size := elem.Size
+	if size == 0 {
+		size = 64
+	}
+
+	if size < 8 || size > 256 || size%8 != 0 {
+		return nil, nil, fmt.Errorf("invalid integer size %d", elem.Size)
+	}
+
+	switch elem.Type {
+	case types.Uint:
+		max := new(big.Int).Lsh(big.NewInt(1), uint(size))
+		return new(big.Int), max.Sub(max, big.NewInt(1)), nil
+	case types.Int:
+		max := new(big.Int).Lsh(big.NewInt(1), uint(size-1))
+		min := new(big.Int).Neg(max)
+		return min, max.Sub(max, big.NewInt(1)), nil
+	}
+
+	return nil, nil, fmt.Errorf("`IntegerBounds` does not support type %q", elem.Type)
+}
+
+// IntegerCode identifies why a value is not an integer of an element
+type IntegerCode int
+
+const (
+	// IntegerMismatch reports a value that is neither a JSON number nor a string
+	IntegerMismatch IntegerCode = iota + 1
+	// IntegerInvalidFormat reports a number or string that is not a decimal integer, e.g. `1.5` or `0x10`
+	IntegerInvalidFormat
+	// IntegerOutOfRange reports an integer that doesn't fit the element size and signedness
+	IntegerOutOfRange
+	// IntegerInvalidSize reports an element whose size or type has no integer bounds
+	IntegerInvalidSize
+)
+
+// IntegerError describes a value rejected by ParseInteger or CheckInteger
+type IntegerError struct {
+	Code IntegerCode
+	// Min and Max are the bounds of the element, set when the integer is out of range
+	Min, Max *big.Int
+	// Err is the cause of an invalid size
+	Err error
+}
+
+// Error implements the error interface
+func (e *IntegerError) Error() string {
+	switch e.Code {
+	case IntegerMismatch:
+		return "expected an integer"
+	case IntegerInvalidFormat:
+		return "expected an integer without fraction or exponent"
+	case IntegerOutOfRange:
+		return fmt.Sprintf("value must be between %s and %s", e.Min, e.Max)
+	}
+	return e.Err.Error()
+}
+
+// ParseInteger parses a decoded JSON value as an integer of the element and checks that it fits
+// Integers are JSON numbers or decimal strings, the form Unweld renders integers wider than 53 bits in
+// Returns an *IntegerError if the value is rejected, the parsed integer being kept when it is out of range
+func ParseInteger(elem types.Element, value any) (*big.Int, error) {
+	var raw string
+	switch val := value.(type) {
+	case json.Number:
+		raw = val.String()
+	case string:
+		raw = val
+	default:
+		return nil, &IntegerError{Code: IntegerMismatch}
+	}
+
+	number, ok := new(big.Int).SetString(raw, 10)
+	if !ok {
+		return nil, &IntegerError{Code: IntegerInvalidFormat}
+	}
+
+	return number, CheckInteger(elem, number)
+}
+
+// CheckInteger checks that the integer fits the element size and signedness
+// Returns an *IntegerError if it doesn't or if the element has no integer bounds
+func CheckInteger(elem types.Element, number *big.Int) error {
+	min, max, err := IntegerBounds(elem)
+	if err != nil {
+		return &IntegerError{Code: IntegerInvalidSize, Err: err}
+	}
+
+	if number.Cmp(min) < 0 || number.Cmp(max) > 0 {
+		return &IntegerError{Code: IntegerOutOfRange, Min: min, Max: max}
+	}
+
+	return nil
+}
+
+// TypeName returns the sized name of a numeric element, e.g. `uint256` or `int64`
+func TypeName(elem types.Element) string {
+	if elem.Size == 0 && (elem.Type == types.Int || elem.Type == types.Uint) {
+		return fmt.Sprintf("%s64", elem.Type)
+	}
+
+	if elem.Size == 0 {
+		return string(elem.Type)
+	}
+
+	return fmt.Sprintf("%s%d", elem.Type, elem.Size)
+}
@@ -0,0 +1,229 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+// IntegerMode defines how integers are described in exported schemas
+type IntegerMode int
+
+const (
+	// IntegerBounds describes integers as JSON numbers with their exact minimum and maximum
+	// This is the form accepted by welding
+	IntegerBounds IntegerMode = iota
+	// IntegerStrings describes integers wider than 53 bits as decimal strings matching a pattern
+	// This is the form produced by unwelding with the default integer format
+	IntegerStrings
+)
+
+// maxSafeIntegerBits is the width of the largest integer a float64 represents exactly
+const maxSafeIntegerBits = 53
+
+// Option contains configuration options for Export
+type Option struct {
+	// IntegerMode defines how integers are described, IntegerBounds by default
+	IntegerMode IntegerMode
+}
+
+// Export converts a schema into a JSON Schema (draft 2020-12) document describing its JSON payload
+// Top-level elements become a `prefixItems` tuple, the way Weld payloads are shaped,
+// and trailing elements that are optional or have a default may be omitted
+// Returns an error if an element cannot be described
+func Export(elements types.Elements, opts ...Option) (*Schema, error) {
+	var opt Option
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	items := make([]*Schema, len(elements))
+	required := 0
+	for i, elem := range elements {
+		item, err := export(elem, opt)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		items[i] = item
+
+		if isRequired(elem) {
+			required = i + 1
+		}
+	}
+
+	return &Schema{
+		Schema:      Draft,
+		Type:        Types{"array"},
+		PrefixItems: items,
+		Items:       Bool(false),
+		MinItems:    &required,
+		MaxItems:    ptr(len(elements)),
+	}, nil
+}
+
+// ExportElement converts a single element into a JSON Schema describing its JSON value
+func ExportElement(elem types.Element, opts ...Option) (*Schema, error) {
+	var opt Option
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	return export(elem, opt)
+}
+
+// export dispatches to the appropriate type-specific exporter based on the element type
+// Optional elements additionally accept null
+func export(elem types.Element, opt Option) (*Schema, error) {
+	var (
+		s   *Schema
+		err error
+	)
+
+	switch elem.Type {
+	case types.String:
+		s = &Schema{Type: Types{"string"}}
+	case types.Bool:
+		s = &Schema{Type: Types{"boolean"}}
+	case types.Float:
+		s = &Schema{Type: Types{"number"}}
+	case types.Address:
+		s = hexSchema(20)
+	case types.Bytes:
+		s = hexSchema(elem.Size)
+	case types.Int, types.Uint:
+		s, err = exportNumber(elem, opt)
+	case types.Array:
+		s, err = exportArray(elem, opt)
+	case types.Object:
+		s, err = exportObject(elem, opt)
+	default:
+		err = fmt.Errorf("json schema export does not support %q", elem.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.Title = elem.Name
+	if elem.Default != nil {
+		s.Default = elem.Default
+	}
+
+	if elem.Optional {
+		s.Type = append(s.Type, "null")
+	}
+
+	return s, nil
+}
+
+// exportNumber describes an integer by its bounds, or by a decimal pattern in IntegerStrings mode
+func exportNumber(elem types.Element, opt Option) (*Schema, error) {
+	min, max, err := utils.IntegerBounds(elem)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.IntegerMode == IntegerStrings && max.BitLen() > maxSafeIntegerBits {
+		pattern := "^(0|[1-9][0-9]*)$"
+		length := len(max.String())
+		if elem.Type == types.Int {
+			pattern = "^(0|-?[1-9][0-9]*)$"
+			length = len(min.String())
+		}
+
+		return &Schema{
+			Type:        Types{"string"},
+			Pattern:     pattern,
+			MinLength:   ptr(1),
+			MaxLength:   &length,
+			Description: fmt.Sprintf("%s as a decimal string between %s and %s", utils.TypeName(elem), min, max),
+		}, nil
+	}
+
+	return &Schema{
+		Type:    Types{"integer"},
+		Minimum: json.Number(min.String()),
+		Maximum: json.Number(max.String()),
+	}, nil
+}
+
+// exportArray describes a dynamic array, or a fixed-size array through minItems and maxItems
+// Fixed-size arrays whose items have a default may be shorter, welding pads them
+func exportArray(elem types.Element, opt Option) (*Schema, error) {
+	if len(elem.Children) != 1 {
+		return nil, fmt.Errorf("array must have one child")
+	}
+
+	items, err := export(elem.Children[0], opt)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Schema{Type: Types{"array"}, Items: items}
+	if elem.Size > 0 {
+		s.MaxItems = ptr(elem.Size)
+		if elem.Children[0].Default == nil {
+			s.MinItems = ptr(elem.Size)
+		}
+	}
+
+	return s, nil
+}
+
+// exportObject describes an object whose properties are the children of the element
+// Children that are neither optional nor defaulted are required, and unknown properties are rejected
+func exportObject(elem types.Element, opt Option) (*Schema, error) {
+	if len(elem.Children) == 0 {
+		return nil, fmt.Errorf("object must have at least one child")
+	}
+
+	s := &Schema{
+		Type:                 Types{"object"},
+		Properties:           make(Properties, len(elem.Children)),
+		Required:             make([]string, 0, len(elem.Children)),
+		AdditionalProperties: Bool(false),
+	}
+
+	for i, child := range elem.Children {
+		property, err := export(child, opt)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", child.Name, err)
+		}
+
+		// The name is the property key, repeating it as title adds nothing
+		property.Title = ""
+		s.Properties[i] = Property{Name: child.Name, Schema: property}
+
+		if isRequired(child) {
+			s.Required = append(s.Required, child.Name)
+		}
+	}
+
+	return s, nil
+}
+
+// hexSchema describes 0x-prefixed hex of the given number of bytes, or of any number of bytes if size is 0
+func hexSchema(size int) *Schema {
+	if size <= 0 {
+		return &Schema{Type: Types{"string"}, Pattern: "^0x([0-9a-fA-F]{2})*$"}
+	}
+
+	return &Schema{
+		Type:      Types{"string"},
+		Pattern:   fmt.Sprintf("^0x[0-9a-fA-F]{%d}$", size*2),
+		MinLength: ptr(size*2 + 2),
+		MaxLength: ptr(size*2 + 2),
+	}
+}
+
+// isRequired reports whether a payload must hold a value for the element
+func isRequired(elem types.Element) bool {
+	return !elem.Optional && elem.Default == nil
+}
+
+// ptr returns a pointer to the value
+func ptr[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,139 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport(t *testing.T) {
+	type Testcase struct {
+		Name     string
+		Input    types.Elements
+		Option   Option
+		Expected string
+	}
+
+	testcases := []Testcase{
+		{
+			Name:  "primitives",
+			Input: types.Elements{{Name: "memo", Type: types.String}, {Type: types.Bool}, {Type: types.Address}, {Type: types.Bytes}, {Type: types.Bytes, Size: 4}},
+			Expected: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "array",
+				"prefixItems": [
+					{"title": "memo", "type": "string"},
+					{"type": "boolean"},
+					{"type": "string", "pattern": "^0x[0-9a-fA-F]{40}$", "minLength": 42, "maxLength": 42},
+					{"type": "string", "pattern": "^0x([0-9a-fA-F]{2})*$"},
+					{"type": "string", "pattern": "^0x[0-9a-fA-F]{8}$", "minLength": 10, "maxLength": 10}
+				],
+				"items": false,
+				"minItems": 5,
+				"maxItems": 5
+			}`,
+		},
+		{
+			Name:  "integer-bounds",
+			Input: types.Elements{{Type: types.Uint, Size: 8}, {Type: types.Int}, {Type: types.Uint, Size: 256}},
+			Expected: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "array",
+				"prefixItems": [
+					{"type": "integer", "minimum": 0, "maximum": 255},
+					{"type": "integer", "minimum": -9223372036854775808, "maximum": 9223372036854775807},
+					{"type": "integer", "minimum": 0, "maximum": 115792089237316195423570985008687907853269984665640564039457584007913129639935}
+				],
+				"items": false,
+				"minItems": 3,
+				"maxItems": 3
+			}`,
+		},
+		{
+			Name:   "integer-strings",
+			Input:  types.Elements{{Type: types.Uint, Size: 32}, {Type: types.Int, Size: 128}},
+			Option: Option{IntegerMode: IntegerStrings},
+			Expected: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "array",
+				"prefixItems": [
+					{"type": "integer", "minimum": 0, "maximum": 4294967295},
+					{"type": "string", "pattern": "^(0|-?[1-9][0-9]*)$", "minLength": 1, "maxLength": 40, "description": "int128 as a decimal string between -170141183460469231731687303715884105728 and 170141183460469231731687303715884105727"}
+				],
+				"items": false,
+				"minItems": 2,
+				"maxItems": 2
+			}`,
+		},
+		{
+			Name: "arrays-objects-and-optionals",
+			Input: types.Elements{
+				{Type: types.Object, Children: types.Elements{
+					{Name: "owner", Type: types.Address},
+					{Name: "memo", Type: types.String, Optional: true},
+					{Name: "deadline", Type: types.Uint, Size: 8, Default: json.RawMessage(`0`)},
+					{Name: "tags", Type: types.Array, Size: 2, Children: types.Elements{{Type: types.Bool}}},
+				}},
+				{Type: types.Array, Size: 3, Children: types.Elements{{Type: types.Uint, Size: 8, Default: json.RawMessage(`7`)}}},
+				{Type: types.String, Optional: true},
+			},
+			Expected: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "array",
+				"prefixItems": [
+					{
+						"type": "object",
+						"properties": {
+							"owner": {"type": "string", "pattern": "^0x[0-9a-fA-F]{40}$", "minLength": 42, "maxLength": 42},
+							"memo": {"type": ["string", "null"]},
+							"deadline": {"type": "integer", "minimum": 0, "maximum": 255, "default": 0},
+							"tags": {"type": "array", "items": {"type": "boolean"}, "minItems": 2, "maxItems": 2}
+						},
+						"required": ["owner", "tags"],
+						"additionalProperties": false
+					},
+					{"type": "array", "items": {"type": "integer", "minimum": 0, "maximum": 255, "default": 7}, "maxItems": 3},
+					{"type": ["string", "null"]}
+				],
+				"items": false,
+				"minItems": 2,
+				"maxItems": 3
+			}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			schema, err := Export(tc.Input, tc.Option)
+			assert.NoError(t, err)
+
+			actual, err := json.Marshal(schema)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.Expected, string(actual))
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Export(types.Elements{{Type: types.Uint, Size: 7}})
+		assert.Error(t, err)
+
+		_, err = Export(types.Elements{{Type: types.Object}})
+		assert.Error(t, err)
+	})
+}
+
+func TestSchema_UnmarshalJSON(t *testing.T) {
+	var schema Schema
+	err := json.Unmarshal([]byte(`{"type": ["object", "null"], "properties": {"b": {"type": "string"}, "a": true}, "additionalProperties": false}`), &schema)
+	assert.NoError(t, err)
+	assert.Equal(t, Types{"object", "null"}, schema.Type)
+	assert.Equal(t, "b", schema.Properties[0].Name)
+	assert.Equal(t, Bool(true), schema.Properties.Get("a"))
+	assert.Equal(t, Bool(false), schema.AdditionalProperties)
+
+	actual, err := json.Marshal(schema)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":["object","null"],"properties":{"b":{"type":"string"},"a":true},"additionalProperties":false}`, string(actual))
+}
@@ -0,0 +1,413 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+var (
+	// fixedHexPattern matches patterns of hex strings with an exact number of digits, e.g. `^0x[0-9a-fA-F]{40}$`
+	fixedHexPattern = regexp.MustCompile(`^\^0x\[[0-9a-fA-F-]+\]\{(\d+)\}\$$`)
+	// dynamicHexPattern matches patterns of hex strings of any number of bytes, e.g. `^0x([0-9a-fA-F]{2})*$`
+	dynamicHexPattern = regexp.MustCompile(`^\^0x(\(\[[0-9a-fA-F-]+\]\{2\}\)\*|\[[0-9a-fA-F-]+\]\*)\$$`)
+	// decimalPattern matches patterns of decimal integer strings, e.g. `^(0|-?[1-9][0-9]*)$`
+	decimalPattern = regexp.MustCompile(`^\^\(0\|(-\?)?\[1-9\]\[0-9\]\*\)\$$`)
+)
+
+// Import converts a JSON Schema document into a schema
+// A `prefixItems` tuple at the root, the shape produced by Export, yields one element per item,
+// while any other root schema yields a single element
+// Returns an error for constructs that cannot be represented, e.g. `oneOf`
+func Import(data []byte) (types.Elements, error) {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid json schema: %w", err)
+	}
+
+	return ImportSchema(&s)
+}
+
+// ImportSchema converts a parsed JSON Schema document into a schema
+func ImportSchema(s *Schema) (types.Elements, error) {
+	im := &importer{root: s, resolving: make(map[string]bool)}
+
+	resolved, path, err := im.resolve(s, "#")
+	if err != nil {
+		return nil, err
+	}
+
+	if resolved.Boolean == nil && resolved.PrefixItems != nil {
+		elements := make(types.Elements, len(resolved.PrefixItems))
+		for i, item := range resolved.PrefixItems {
+			elem, err := im.element(item, fmt.Sprintf("%s/prefixItems/%d", path, i))
+			if err != nil {
+				return nil, err
+			}
+
+			// Items past minItems may be omitted, the way optional elements are, and minItems defaults to 0
+			if (resolved.MinItems == nil || i >= *resolved.MinItems) && elem.Default == nil {
+				elem.Optional = true
+			}
+			elements[i] = elem
+		}
+		return elements, nil
+	}
+
+	elem, err := im.element(s, "#")
+	if err != nil {
+		return nil, err
+	}
+
+	return types.Elements{elem}, nil
+}
+
+// importer converts subschemas of a root document, resolving references against it
+type importer struct {
+	root *Schema
+	// resolving holds the references being resolved, to detect cycles
+	resolving map[string]bool
+}
+
+// element converts a subschema into an element, the title becoming the element name
+func (im *importer) element(s *Schema, path string) (types.Element, error) {
+	ref := ""
+	if s.Boolean == nil {
+		ref = s.Ref
+	}
+
+	if ref != "" {
+		if im.resolving[ref] {
+			return types.Element{}, fmt.Errorf("%s: recursive reference %q cannot be represented", path, ref)
+		}
+		im.resolving[ref] = true
+		defer delete(im.resolving, ref)
+	}
+
+	resolved, path, err := im.resolve(s, path)
+	if err != nil {
+		return types.Element{}, err
+	}
+
+	if err := unsupported(resolved, path); err != nil {
+		return types.Element{}, err
+	}
+
+	ty, optional, err := typeOf(resolved, path)
+	if err != nil {
+		return types.Element{}, err
+	}
+
+	var elem types.Element
+	switch ty {
+	case "string":
+		elem, err = importString(resolved, path)
+	case "integer":
+		elem, err = importInteger(resolved, path)
+	case "number":
+		err = fmt.Errorf("%s: non-integer numbers are not supported", path)
+	case "boolean":
+		elem = types.Element{Type: types.Bool}
+	case "array":
+		elem, err = im.importArray(resolved, path)
+	case "object":
+		elem, err = im.importObject(resolved, path)
+	default:
+		err = fmt.Errorf("%s: type %q cannot be represented", path, ty)
+	}
+
+	if err != nil {
+		return types.Element{}, err
+	}
+
+	elem.Name = resolved.Title
+	if s.Title != "" {
+		elem.Name = s.Title
+	}
+	elem.Optional = optional
+	elem.Default = resolved.Default
+	if s.Default != nil {
+		elem.Default = s.Default
+	}
+
+	return elem, nil
+}
+
+// resolve follows `$ref` until a schema without reference is reached
+// Returns the resolved schema together with its path in the document
+func (im *importer) resolve(s *Schema, path string) (*Schema, string, error) {
+	for depth := 0; s.Boolean == nil && s.Ref != ""; depth++ {
+		if depth > len(im.root.Defs)+len(im.root.Definitions) {
+			return nil, "", fmt.Errorf("%s: reference cycle through %q", path, s.Ref)
+		}
+
+		target, err := im.lookup(s.Ref)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %w", path, err)
+		}
+
+		s, path = target, s.Ref
+	}
+
+	return s, path, nil
+}
+
+// lookup finds the subschema addressed by a local reference such as `#/$defs/Account`
+func (im *importer) lookup(ref string) (*Schema, error) {
+	if ref == "#" {
+		return im.root, nil
+	}
+
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil, fmt.Errorf("only local references are supported, got %q", ref)
+	}
+
+	tokens := strings.Split(pointer, "/")
+	if len(tokens) != 2 {
+		return nil, fmt.Errorf("reference %q must point into $defs or definitions", ref)
+	}
+
+	name := strings.NewReplacer("~1", "/", "~0", "~").Replace(tokens[1])
+
+	var defs map[string]*Schema
+	switch tokens[0] {
+	case "$defs":
+		defs = im.root.Defs
+	case "definitions":
+		defs = im.root.Definitions
+	default:
+		return nil, fmt.Errorf("reference %q must point into $defs or definitions", ref)
+	}
+
+	target, ok := defs[name]
+	if !ok || target == nil {
+		return nil, fmt.Errorf("unresolved reference %q", ref)
+	}
+
+	return target, nil
+}
+
+// importString converts a string schema into an address, bytes, integer or plain string element
+// The kind is recognized from the hex and decimal patterns produced by Export
+// Fixed-length hex patterns over 32 bytes have no ABI type and are rejected
+func importString(s *Schema, path string) (types.Element, error) {
+	if match := fixedHexPattern.FindStringSubmatch(s.Pattern); match != nil {
+		digits, err := strconv.Atoi(match[1])
+		if err != nil || digits == 0 || digits%2 != 0 {
+			return types.Element{}, fmt.Errorf("%s: pattern %q must describe whole bytes", path, s.Pattern)
+		}
+
+		// Addresses and bytes20 share the same pattern, the address being the common case
+		switch size := digits / 2; {
+		case size == 20:
+			return types.Element{Type: types.Address}, nil
+		case size <= 32:
+			return types.Element{Type: types.Bytes, Size: size}, nil
+		default:
+			return types.Element{}, fmt.Errorf("%s: pattern %q describes %d bytes, fixed-size bytes hold at most 32", path, s.Pattern, size)
+		}
+	}
+
+	if dynamicHexPattern.MatchString(s.Pattern) {
+		return types.Element{Type: types.Bytes}, nil
+	}
+
+	// Decimal strings cannot be narrowed by their pattern, they are imported with the widest size
+	if match := decimalPattern.FindStringSubmatch(s.Pattern); match != nil {
+		if match[1] != "" {
+			return types.Element{Type: types.Int, Size: 256}, nil
+		}
+		return types.Element{Type: types.Uint, Size: 256}, nil
+	}
+
+	return types.Element{Type: types.String}, nil
+}
+
+// importInteger converts an integer schema into the narrowest Int or Uint holding its bounds
+// Integers without lower bound are signed, integers without upper bound are 256 bits wide
+func importInteger(s *Schema, path string) (types.Element, error) {
+	min, err := bound(s.Minimum, s.ExclusiveMinimum, false)
+	if err != nil {
+		return types.Element{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	max, err := bound(s.Maximum, s.ExclusiveMaximum, true)
+	if err != nil {
+		return types.Element{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if min != nil && max != nil && min.Cmp(max) > 0 {
+		return types.Element{}, fmt.Errorf("%s: minimum %s exceeds maximum %s", path, min, max)
+	}
+
+	elem := types.Element{Type: types.Int}
+	if min != nil && min.Sign() >= 0 {
+		elem.Type = types.Uint
+	}
+
+	if min == nil || max == nil {
+		elem.Size = 256
+		return elem, nil
+	}
+
+	for size := 8; size <= 256; size += 8 {
+		elem.Size = size
+		lower, upper, _ := utils.IntegerBounds(elem)
+		if min.Cmp(lower) >= 0 && max.Cmp(upper) <= 0 {
+			return elem, nil
+		}
+	}
+
+	return types.Element{}, fmt.Errorf("%s: integers between %s and %s do not fit 256 bits", path, min, max)
+}
+
+// bound returns the inclusive integer bound of an inclusive and an exclusive limit, or nil if there is none
+// Fractional limits are rounded towards the inside of the range
+func bound(inclusive, exclusive json.Number, upper bool) (*big.Int, error) {
+	var result *big.Int
+	for i, limit := range []json.Number{inclusive, exclusive} {
+		if limit == "" {
+			continue
+		}
+
+		rat, ok := new(big.Rat).SetString(limit.String())
+		if !ok {
+			return nil, fmt.Errorf("invalid bound %q", limit)
+		}
+
+		value := new(big.Int).Quo(rat.Num(), rat.Denom())
+		switch {
+		case upper && rat.Sign() < 0 && !rat.IsInt():
+			value.Sub(value, big.NewInt(1))
+		case !upper && rat.Sign() > 0 && !rat.IsInt():
+			value.Add(value, big.NewInt(1))
+		}
+
+		if i == 1 && rat.IsInt() {
+			if upper {
+				value.Sub(value, big.NewInt(1))
+			} else {
+				value.Add(value, big.NewInt(1))
+			}
+		}
+
+		if result == nil || (upper && value.Cmp(result) < 0) || (!upper && value.Cmp(result) > 0) {
+			result = value
+		}
+	}
+
+	return result, nil
+}
+
+// importArray converts an array schema, minItems equal to maxItems making it fixed-size
+func (im *importer) importArray(s *Schema, path string) (types.Element, error) {
+	if s.PrefixItems != nil {
+		return types.Element{}, fmt.Errorf("%s: nested prefixItems tuples cannot be represented", path)
+	}
+
+	if s.Items == nil || s.Items.Boolean != nil {
+		return types.Element{}, fmt.Errorf("%s: arrays must declare the schema of their items", path)
+	}
+
+	child, err := im.element(s.Items, path+"/items")
+	if err != nil {
+		return types.Element{}, err
+	}
+
+	elem := types.Element{Type: types.Array, Children: types.Elements{child}}
+	if s.MaxItems != nil && *s.MaxItems > 0 && (s.MinItems != nil && *s.MinItems == *s.MaxItems || child.Default != nil) {
+		elem.Size = *s.MaxItems
+	}
+
+	return elem, nil
+}
+
+// importObject converts an object schema, its properties becoming children in declaration order
+// Properties that are not required and have no default become optional
+func (im *importer) importObject(s *Schema, path string) (types.Element, error) {
+	if len(s.Properties) == 0 {
+		return types.Element{}, fmt.Errorf("%s: objects must declare at least one property", path)
+	}
+
+	required := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	elem := types.Element{Type: types.Object, Children: make(types.Elements, len(s.Properties))}
+	for i, property := range s.Properties {
+		child, err := im.element(property.Schema, path+"/properties/"+utils.JSONPointer("", property.Name)[1:])
+		if err != nil {
+			return types.Element{}, err
+		}
+
+		child.Name = property.Name
+		if !required[property.Name] && child.Default == nil {
+			child.Optional = true
+		}
+		elem.Children[i] = child
+	}
+
+	return elem, nil
+}
+
+// typeOf returns the single non-null type of a schema and whether null is accepted too
+// The type is inferred from the keywords when the schema does not declare it
+func typeOf(s *Schema, path string) (string, bool, error) {
+	if s.Boolean != nil {
+		return "", false, fmt.Errorf("%s: boolean schemas cannot be represented", path)
+	}
+
+	var (
+		types    []string
+		nullable bool
+	)
+
+	for _, ty := range s.Type {
+		if ty == "null" {
+			nullable = true
+			continue
+		}
+		types = append(types, ty)
+	}
+
+	switch {
+	case len(types) == 1:
+		return types[0], nullable, nil
+	case len(types) > 1:
+		return "", false, fmt.Errorf("%s: union of types %v cannot be represented", path, types)
+	case len(s.Properties) > 0:
+		return "object", nullable, nil
+	case s.Items != nil || s.PrefixItems != nil:
+		return "array", nullable, nil
+	}
+
+	return "", false, fmt.Errorf("%s: schema must declare its type", path)
+}
+
+// unsupported reports the first keyword of the schema that cannot be represented by an element
+func unsupported(s *Schema, path string) error {
+	switch {
+	case s.OneOf != nil:
+		return fmt.Errorf("%s: oneOf cannot be represented", path)
+	case s.AnyOf != nil:
+		return fmt.Errorf("%s: anyOf cannot be represented", path)
+	case s.AllOf != nil:
+		return fmt.Errorf("%s: allOf cannot be represented", path)
+	case s.Not != nil:
+		return fmt.Errorf("%s: not cannot be represented", path)
+	case s.Enum != nil:
+		return fmt.Errorf("%s: enum cannot be represented", path)
+	case s.Const != nil:
+		return fmt.Errorf("%s: const cannot be represented", path)
+	}
+
+	return nil
+}
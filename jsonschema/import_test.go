@@ -0,0 +1,168 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImport(t *testing.T) {
+	type Testcase struct {
+		Name     string
+		Input    string
+		Expected types.Elements
+	}
+
+	testcases := []Testcase{
+		{
+			Name: "integer-bounds",
+			Input: `{"type": "array", "prefixItems": [
+				{"type": "integer", "minimum": 0, "maximum": 255},
+				{"type": "integer", "minimum": 0, "maximum": 256},
+				{"type": "integer", "minimum": -129, "maximum": 0},
+				{"type": "integer", "exclusiveMinimum": -129, "exclusiveMaximum": 128},
+				{"type": "integer", "minimum": 0},
+				{"type": "integer"},
+				{"type": "integer", "minimum": 0.5, "maximum": 1e3}
+			], "minItems": 7}`,
+			Expected: types.Elements{
+				{Type: types.Uint, Size: 8},
+				{Type: types.Uint, Size: 16},
+				{Type: types.Int, Size: 16},
+				{Type: types.Int, Size: 8},
+				{Type: types.Uint, Size: 256},
+				{Type: types.Int, Size: 256},
+				{Type: types.Uint, Size: 16},
+			},
+		},
+		{
+			Name: "strings",
+			Input: `{"type": "array", "prefixItems": [
+				{"title": "memo", "type": "string"},
+				{"type": "string", "pattern": "^0x[0-9a-fA-F]{40}$"},
+				{"type": "string", "pattern": "^0x[0-9a-f]{64}$"},
+				{"type": "string", "pattern": "^0x([0-9a-fA-F]{2})*$"},
+				{"type": "string", "pattern": "^(0|-?[1-9][0-9]*)$"},
+				{"type": "boolean"}
+			], "minItems": 6}`,
+			Expected: types.Elements{
+				{Name: "memo", Type: types.String},
+				{Type: types.Address},
+				{Type: types.Bytes, Size: 32},
+				{Type: types.Bytes},
+				{Type: types.Int, Size: 256},
+				{Type: types.Bool},
+			},
+		},
+		{
+			Name: "prefix-items-without-min-items",
+			Input: `{"type": "array", "prefixItems": [
+				{"type": "boolean"},
+				{"type": "string", "default": "a"}
+			]}`,
+			Expected: types.Elements{
+				{Type: types.Bool, Optional: true},
+				{Type: types.String, Default: json.RawMessage(`"a"`)},
+			},
+		},
+		{
+			Name: "refs-and-objects",
+			Input: `{
+				"$defs": {
+					"Amount": {"type": "integer", "minimum": 0, "maximum": 18446744073709551615},
+					"Balance": {"type": "object", "properties": {"currency": {"type": "string"}, "amount": {"$ref": "#/$defs/Amount"}}, "required": ["amount", "currency"]}
+				},
+				"type": "object",
+				"properties": {
+					"owner": {"type": "string", "pattern": "^0x[0-9a-fA-F]{40}$"},
+					"balances": {"type": "array", "items": {"$ref": "#/$defs/Balance"}, "minItems": 2, "maxItems": 2},
+					"memo": {"type": ["string", "null"]},
+					"nonce": {"type": "integer", "minimum": 0, "maximum": 255, "default": 0},
+					"tags": {"type": "array", "items": {"type": "boolean"}}
+				},
+				"required": ["owner", "balances", "tags"]
+			}`,
+			Expected: types.Elements{{Type: types.Object, Children: types.Elements{
+				{Name: "owner", Type: types.Address},
+				{Name: "balances", Type: types.Array, Size: 2, Children: types.Elements{{Type: types.Object, Children: types.Elements{
+					{Name: "currency", Type: types.String},
+					{Name: "amount", Type: types.Uint, Size: 64},
+				}}}},
+				{Name: "memo", Type: types.String, Optional: true},
+				{Name: "nonce", Type: types.Uint, Size: 8, Default: json.RawMessage(`0`)},
+				{Name: "tags", Type: types.Array, Children: types.Elements{{Type: types.Bool}}},
+			}}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := Import([]byte(tc.Input))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestImport_RoundTrip(t *testing.T) {
+	schema := types.Elements{
+		{Name: "key", Type: types.String},
+		{Name: "account", Type: types.Object, Children: types.Elements{
+			{Name: "owner", Type: types.Address},
+			{Name: "memo", Type: types.String, Optional: true},
+			{Name: "limits", Type: types.Array, Size: 3, Children: types.Elements{{Type: types.Int, Size: 128}}},
+			{Name: "balance", Type: types.Object, Children: types.Elements{{Name: "amount", Type: types.Uint, Size: 256}, {Name: "tag", Type: types.Bytes, Size: 4}}},
+		}},
+		{Name: "padding", Type: types.Array, Size: 2, Children: types.Elements{{Type: types.Uint, Size: 8, Default: json.RawMessage(`7`)}}},
+		{Name: "data", Type: types.Bytes, Optional: true},
+	}
+
+	exported, err := Export(schema)
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(exported)
+	assert.NoError(t, err)
+
+	actual, err := Import(data)
+	assert.NoError(t, err)
+	assert.Equal(t, schema, actual)
+}
+
+func TestImportInvalid(t *testing.T) {
+	testcases := map[string]string{
+		"one-of":            `{"oneOf": [{"type": "string"}, {"type": "integer"}]}`,
+		"nested-one-of":     `{"type": "object", "properties": {"value": {"oneOf": [{"type": "string"}, {"type": "integer"}]}}}`,
+		"enum":              `{"type": "string", "enum": ["a", "b"]}`,
+		"union":             `{"type": ["string", "integer"]}`,
+		"untyped":           `{"description": "anything"}`,
+		"boolean-schema":    `true`,
+		"unresolved-ref":    `{"$ref": "#/$defs/Missing"}`,
+		"remote-ref":        `{"$ref": "https://example.com/schema.json"}`,
+		"recursive-ref":     `{"$defs": {"Node": {"type": "object", "properties": {"next": {"$ref": "#/$defs/Node"}}}}, "$ref": "#/$defs/Node"}`,
+		"empty-object":      `{"type": "object"}`,
+		"untyped-items":     `{"type": "array"}`,
+		"too-wide":          `{"type": "integer", "minimum": 0, "maximum": 1e78}`,
+		"inverted-bounds":   `{"type": "integer", "minimum": 10, "maximum": 1}`,
+		"invalid-json":      `{"type": `,
+		"nested-prefixItem": `{"type": "object", "properties": {"pair": {"type": "array", "prefixItems": [{"type": "string"}]}}}`,
+	}
+
+	for name, input := range testcases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Import([]byte(input))
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("non-integer-number", func(t *testing.T) {
+		_, err := Import([]byte(`{"type": "object", "properties": {"price": {"type": "number"}}, "required": ["price"]}`))
+		assert.EqualError(t, err, "#/properties/price: non-integer numbers are not supported")
+	})
+
+	t.Run("unsupported-hex-length", func(t *testing.T) {
+		_, err := Import([]byte(`{"type": "string", "pattern": "^0x[0-9a-fA-F]{66}$"}`))
+		assert.ErrorContains(t, err, "describes 33 bytes, fixed-size bytes hold at most 32")
+	})
+}
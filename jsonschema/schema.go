@@ -0,0 +1,185 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ideatru/welder/internal/utils"
+)
+
+// Draft is the URI of the JSON Schema dialect produced by Export
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document or subschema
+// Only the keywords needed to describe welder payloads are modelled
+type Schema struct {
+	// Boolean holds the value of a boolean schema, `true` accepting anything and `false` nothing
+	// When set, every other field is ignored
+	Boolean *bool `json:"-"`
+
+	Schema      string             `json:"$schema,omitempty"`
+	ID          string             `json:"$id,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Defs        map[string]*Schema `json:"$defs,omitempty"`
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        Types              `json:"type,omitempty"`
+
+	Pattern   string `json:"pattern,omitempty"`
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+
+	Minimum          json.Number `json:"minimum,omitempty"`
+	Maximum          json.Number `json:"maximum,omitempty"`
+	ExclusiveMinimum json.Number `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum json.Number `json:"exclusiveMaximum,omitempty"`
+
+	PrefixItems []*Schema `json:"prefixItems,omitempty"`
+	Items       *Schema   `json:"items,omitempty"`
+	MinItems    *int      `json:"minItems,omitempty"`
+	MaxItems    *int      `json:"maxItems,omitempty"`
+
+	Properties           Properties `json:"properties,omitempty"`
+	Required             []string   `json:"required,omitempty"`
+	AdditionalProperties *Schema    `json:"additionalProperties,omitempty"`
+
+	Default json.RawMessage   `json:"default,omitempty"`
+	Const   json.RawMessage   `json:"const,omitempty"`
+	Enum    []json.RawMessage `json:"enum,omitempty"`
+
+	AllOf []*Schema `json:"allOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	OneOf []*Schema `json:"oneOf,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+}
+
+// schema has the same fields as Schema without its custom marshallers
+type schema Schema
+
+// Bool creates a boolean schema
+func Bool(value bool) *Schema {
+	return &Schema{Boolean: &value}
+}
+
+// MarshalJSON encodes boolean schemas as `true` or `false` and the others as objects
+func (s Schema) MarshalJSON() ([]byte, error) {
+	if s.Boolean != nil {
+		return json.Marshal(*s.Boolean)
+	}
+
+	return json.Marshal(schema(s))
+}
+
+// UnmarshalJSON decodes a schema object or a boolean schema
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*s = Schema{Boolean: &value}
+		return nil
+	}
+
+	var raw schema
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*s = Schema(raw)
+	return nil
+}
+
+// Types is the value of the `type` keyword, a single type name or a list of them
+type Types []string
+
+// MarshalJSON encodes a single type as a string and several types as an array
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON decodes a type name or a list of type names
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = Types{name}
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings")
+	}
+
+	*t = names
+	return nil
+}
+
+// Has reports whether the type list contains the type name
+func (t Types) Has(name string) bool {
+	for _, ty := range t {
+		if ty == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Property is a named subschema of the `properties` keyword
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+// Properties is the value of the `properties` keyword
+// Unlike a map, it keeps the order of the properties, which is the order of tuple components
+type Properties []Property
+
+// Get returns the schema of the named property, or nil if there is none
+func (p Properties) Get(name string) *Schema {
+	for _, property := range p {
+		if property.Name == name {
+			return property.Schema
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes the properties as an object keeping their order
+func (p Properties) MarshalJSON() ([]byte, error) {
+	object := make(utils.OrderedObject, len(p))
+	for i, property := range p {
+		object[i] = utils.OrderedField{Key: property.Name, Value: property.Schema}
+	}
+
+	return json.Marshal(object)
+}
+
+// UnmarshalJSON decodes the properties object keeping the order of its keys
+func (p *Properties) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return fmt.Errorf("properties must be an object")
+	}
+
+	properties := make(Properties, 0)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		property := Property{Name: token.(string), Schema: new(Schema)}
+		if err := decoder.Decode(property.Schema); err != nil {
+			return fmt.Errorf("property %q: %w", property.Name, err)
+		}
+		properties = append(properties, property)
+	}
+
+	*p = properties
+	return nil
+}
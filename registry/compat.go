@@ -0,0 +1,191 @@
+package registry
+
+import (
+	"github.com/ideatru/welder/ether"
+	"github.com/ideatru/welder/types"
+)
+
+// Compatibility classifies a change between two versions of a schema
+// It is a set of flags, a change may keep the ABI, the JSON payloads, both or neither
+type Compatibility uint8
+
+const (
+	// Breaking changes both the ABI encoding and the accepted JSON payloads
+	Breaking Compatibility = 0
+	// ABICompatible keeps the ABI encoding, e.g. renaming fields
+	// Data encoded with the previous version decodes with the next one and selectors are unchanged
+	ABICompatible Compatibility = 1 << 0
+	// JSONCompatible keeps accepting every JSON payload of the previous version, e.g. widening integers
+	// or reordering tuple fields, which changes the ABI encoding
+	JSONCompatible Compatibility = 1 << 1
+	// Compatible keeps both the ABI encoding and the JSON payloads
+	Compatible = ABICompatible | JSONCompatible
+)
+
+// Satisfies reports whether the compatibility includes every flag of the required one
+func (c Compatibility) Satisfies(required Compatibility) bool {
+	return c&required == required
+}
+
+// String returns the name of the compatibility
+func (c Compatibility) String() string {
+	switch c {
+	case Breaking:
+		return "breaking"
+	case ABICompatible:
+		return "abi-compatible"
+	case JSONCompatible:
+		return "json-compatible"
+	case Compatible:
+		return "compatible"
+	}
+	return "unknown"
+}
+
+// Classify compares the previous and next versions of a schema
+// and reports which of their contracts the change keeps
+// ABI compatibility compares the canonical Solidity parameter lists, names being irrelevant
+// JSON compatibility requires every payload accepted by the previous schema to be accepted by the next one
+// with the same meaning: fields keep their names, integers may only widen, fixed-size arrays and bytes
+// may become dynamic, and added fields or trailing elements must be optional or have a default
+// Removed object fields are ignored in old payloads, removed top-level elements are not
+func Classify(prev, next types.Elements) Compatibility {
+	var c Compatibility
+	if abiCompatible(prev, next) {
+		c |= ABICompatible
+	}
+
+	if jsonCompatibleElements(prev, next) {
+		c |= JSONCompatible
+	}
+
+	return c
+}
+
+// abiCompatible reports whether both schemas have the same canonical parameter list
+func abiCompatible(prev, next types.Elements) bool {
+	prevParams, err := ether.FormatParameters(prev)
+	if err != nil {
+		return false
+	}
+
+	nextParams, err := ether.FormatParameters(next)
+	if err != nil {
+		return false
+	}
+
+	return prevParams == nextParams
+}
+
+// jsonCompatibleElements compares top-level elements by position, the way payload arrays are welded
+func jsonCompatibleElements(prev, next types.Elements) bool {
+	if len(next) < len(prev) {
+		return false
+	}
+
+	for i := range prev {
+		if !jsonCompatible(prev[i], next[i]) {
+			return false
+		}
+	}
+
+	for _, elem := range next[len(prev):] {
+		if !omittable(elem) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// jsonCompatible reports whether every JSON value of the previous element is accepted by the next one
+func jsonCompatible(prev, next types.Element) bool {
+	// Payloads may hold null for optional elements and omit elements with a default
+	if prev.Optional && !next.Optional {
+		return false
+	}
+
+	if prev.Default != nil && !omittable(next) {
+		return false
+	}
+
+	switch prev.Type {
+	case types.Int, types.Uint:
+		return integerWidens(prev, next)
+	case types.Bytes:
+		if next.Type != types.Bytes {
+			return false
+		}
+		return next.Size == 0 || next.Size == prev.Size
+	case types.Array:
+		if next.Type != types.Array || len(prev.Children) != 1 || len(next.Children) != 1 {
+			return false
+		}
+
+		if next.Size != 0 && next.Size != prev.Size {
+			return false
+		}
+		return jsonCompatible(prev.Children[0], next.Children[0])
+	case types.Object:
+		if next.Type != types.Object {
+			return false
+		}
+		return jsonCompatibleFields(prev.Children, next.Children)
+	}
+
+	return prev.Type == next.Type
+}
+
+// jsonCompatibleFields matches object fields by name, their order is irrelevant to JSON
+// Removed fields keep payloads holding them accepted since Weld and the encoders ignore unknown keys
+func jsonCompatibleFields(prev, next types.Elements) bool {
+	fields := make(map[string]types.Element, len(next))
+	for _, child := range next {
+		fields[child.Name] = child
+	}
+
+	for _, child := range prev {
+		field, ok := fields[child.Name]
+		if !ok {
+			continue
+		}
+
+		if !jsonCompatible(child, field) {
+			return false
+		}
+		delete(fields, child.Name)
+	}
+
+	for _, child := range fields {
+		if !omittable(child) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// integerWidens reports whether the next integer holds every value of the previous one
+func integerWidens(prev, next types.Element) bool {
+	prevSize, nextSize := integerSize(prev), integerSize(next)
+	switch {
+	case prev.Type == next.Type:
+		return nextSize >= prevSize
+	case prev.Type == types.Uint && next.Type == types.Int:
+		return nextSize > prevSize
+	}
+	return false
+}
+
+// integerSize returns the size of an integer, 64 bits when unspecified
+func integerSize(elem types.Element) int {
+	if elem.Size == 0 {
+		return 64
+	}
+	return elem.Size
+}
+
+// omittable reports whether a payload may leave the element out
+func omittable(elem types.Element) bool {
+	return elem.Optional || elem.Default != nil
+}
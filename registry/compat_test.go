@@ -0,0 +1,166 @@
+package registry
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	order := types.Elements{
+		{Name: "maker", Type: types.Address},
+		{Name: "order", Type: types.Object, Children: types.Elements{
+			{Name: "amount", Type: types.Uint, Size: 128},
+			{Name: "price", Type: types.Uint, Size: 64},
+			{Name: "tags", Type: types.Array, Size: 2, Children: types.Elements{{Type: types.Bytes, Size: 4}}},
+		}},
+	}
+
+	type Testcase struct {
+		Name     string
+		Next     types.Elements
+		Expected Compatibility
+	}
+
+	testcases := []Testcase{
+		{
+			Name:     "identical",
+			Next:     order,
+			Expected: Compatible,
+		},
+		{
+			Name: "unsized integer",
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				order[1].Children[0], {Name: "price", Type: types.Uint}, order[1].Children[2],
+			}}},
+			Expected: Compatible,
+		},
+		{
+			Name: "renamed top-level element",
+			Next: types.Elements{
+				{Name: "owner", Type: types.Address},
+				order[1],
+			},
+			Expected: Compatible,
+		},
+		{
+			Name: "renamed field",
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				{Name: "quantity", Type: types.Uint, Size: 128}, order[1].Children[1], order[1].Children[2],
+			}}},
+			Expected: ABICompatible,
+		},
+		{
+			Name: "reordered fields",
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				order[1].Children[1], order[1].Children[0], order[1].Children[2],
+			}}},
+			Expected: JSONCompatible,
+		},
+		{
+			Name: "widened integer",
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				{Name: "amount", Type: types.Uint, Size: 256}, order[1].Children[1], order[1].Children[2],
+			}}},
+			Expected: JSONCompatible,
+		},
+		{
+			Name: "unsigned to wider signed integer",
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				{Name: "amount", Type: types.Int, Size: 136}, order[1].Children[1], order[1].Children[2],
+			}}},
+			Expected: JSONCompatible,
+		},
+		{
+			Name: "unsigned to signed integer of the same size",
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				{Name: "amount", Type: types.Int, Size: 128}, order[1].Children[1], order[1].Children[2],
+			}}},
+			Expected: Breaking,
+		},
+		{
+			Name: "narrowed integer",
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				{Name: "amount", Type: types.Uint, Size: 64}, order[1].Children[1], order[1].Children[2],
+			}}},
+			Expected: Breaking,
+		},
+		{
+			Name: "fixed array to dynamic array of dynamic bytes",
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				order[1].Children[0], order[1].Children[1],
+				{Name: "tags", Type: types.Array, Children: types.Elements{{Type: types.Bytes}}},
+			}}},
+			Expected: JSONCompatible,
+		},
+		{
+			Name: "dynamic array to fixed array",
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				order[1].Children[0], order[1].Children[1],
+				{Name: "tags", Type: types.Array, Size: 3, Children: types.Elements{{Type: types.Bytes, Size: 4}}},
+			}}},
+			Expected: Breaking,
+		},
+		{
+			Name: "added optional field",
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				order[1].Children[0], order[1].Children[1], order[1].Children[2],
+				{Name: "memo", Type: types.String, Optional: true},
+			}}},
+			Expected: JSONCompatible,
+		},
+		{
+			Name: "added required field",
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				order[1].Children[0], order[1].Children[1], order[1].Children[2],
+				{Name: "memo", Type: types.String},
+			}}},
+			Expected: Breaking,
+		},
+		{
+			Name:     "added trailing element with a default",
+			Next:     append(types.Elements{order[0], order[1]}, types.Element{Name: "deadline", Type: types.Uint, Default: json.RawMessage(`0`)}),
+			Expected: JSONCompatible,
+		},
+		{
+			Name: "removed field",
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				order[1].Children[0], order[1].Children[2],
+			}}},
+			Expected: JSONCompatible,
+		},
+		{
+			Name:     "removed element",
+			Next:     order[:1],
+			Expected: Breaking,
+		},
+		{
+			Name:     "made optional",
+			Next:     types.Elements{{Name: "maker", Type: types.Address, Optional: true}, order[1]},
+			Expected: Compatible,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, Classify(order, tc.Next))
+		})
+	}
+
+	t.Run("made required", func(t *testing.T) {
+		optional := types.Elements{{Name: "maker", Type: types.Address, Optional: true}, order[1]}
+		assert.Equal(t, ABICompatible, Classify(optional, order))
+	})
+}
+
+func TestCompatibility(t *testing.T) {
+	assert.True(t, Compatible.Satisfies(ABICompatible))
+	assert.True(t, JSONCompatible.Satisfies(Breaking))
+	assert.False(t, JSONCompatible.Satisfies(ABICompatible))
+	assert.False(t, Breaking.Satisfies(Compatible))
+
+	assert.Equal(t, "abi-compatible", ABICompatible.String())
+	assert.Equal(t, "breaking", Breaking.String())
+}
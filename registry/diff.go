@@ -0,0 +1,355 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+// ChangeKind describes the kind of difference between two versions of an element
+type ChangeKind string
+
+const (
+	// ChangeAdded reports an element only present in the next version, After holds it
+	ChangeAdded = ChangeKind("added")
+	// ChangeRemoved reports an element only present in the previous version, Before holds it
+	ChangeRemoved = ChangeKind("removed")
+	// ChangeRenamed reports an element of the same shape under another name
+	ChangeRenamed = ChangeKind("renamed")
+	// ChangeReordered reports an element moved to another position, Before and After are its indexes
+	ChangeReordered = ChangeKind("reordered")
+	// ChangeType reports an element whose type changed, Before and After are type names
+	ChangeType = ChangeKind("type")
+	// ChangeWidened reports an integer or fixed-size bytes element growing in size
+	ChangeWidened = ChangeKind("widened")
+	// ChangeNarrowed reports an integer or fixed-size bytes element shrinking in size
+	ChangeNarrowed = ChangeKind("narrowed")
+	// ChangeResized reports a fixed-size array changing its length
+	ChangeResized = ChangeKind("resized")
+	// ChangeFixedToDynamic reports a fixed-size array or bytes element becoming dynamic
+	ChangeFixedToDynamic = ChangeKind("fixed_to_dynamic")
+	// ChangeDynamicToFixed reports a dynamic array or bytes element becoming fixed-size
+	ChangeDynamicToFixed = ChangeKind("dynamic_to_fixed")
+	// ChangeOptional reports an element becoming optional or required
+	ChangeOptional = ChangeKind("optional")
+	// ChangeDefault reports an element whose default value changed
+	ChangeDefault = ChangeKind("default")
+	// ChangeIndexed reports an event input becoming indexed or not
+	ChangeIndexed = ChangeKind("indexed")
+)
+
+// Change records a single difference between two versions of a schema
+type Change struct {
+	// Path is the JSON pointer of the element in payloads, e.g. `/1/order/amount`
+	// Array items are addressed by `*`, e.g. `/1/tags/*`
+	Path string `json:"path"`
+	// Kind is the kind of difference
+	Kind ChangeKind `json:"kind"`
+	// Before is the previous value of what changed, nil for added elements
+	Before any `json:"before"`
+	// After is the next value of what changed, nil for removed elements
+	After any `json:"after"`
+}
+
+// String describes the change for reviews, e.g. `/1/amount: widened uint128 -> uint256`
+func (c Change) String() string {
+	path := c.Path
+	if path == "" {
+		path = "/"
+	}
+
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("%s: added %s", path, describe(c.After.(types.Element)))
+	case ChangeRemoved:
+		return fmt.Sprintf("%s: removed %s", path, describe(c.Before.(types.Element)))
+	case ChangeDefault:
+		return fmt.Sprintf("%s: default %s -> %s", path, rawString(c.Before), rawString(c.After))
+	}
+
+	return fmt.Sprintf("%s: %s %v -> %v", path, c.Kind, c.Before, c.After)
+}
+
+// Diff walks the previous and next versions of a schema and reports every difference with its path
+// Top-level elements and object fields are matched by name, or by position when some are unnamed
+// Unmatched elements of the same shape are reported as renamed, the others as removed and added,
+// and matched elements out of their relative order as reordered
+func Diff(prev, next types.Elements) []Change {
+	changes := make([]Change, 0)
+	diffElements(prev, next, "", true, &changes)
+	return changes
+}
+
+// diffElements matches two lists of elements and compares the pairs
+// Top-level elements are addressed by index and object fields by name
+func diffElements(prev, next types.Elements, path string, topLevel bool, changes *[]Change) {
+	token := func(elements types.Elements, i int) any {
+		if topLevel {
+			return i
+		}
+		return elements[i].Name
+	}
+
+	pairs := match(prev, next)
+	moved := reordered(pairs, len(next))
+
+	matched := make([]bool, len(prev))
+	for j, elem := range next {
+		i, ok := pairs[j]
+		if !ok {
+			*changes = append(*changes, Change{Path: utils.JSONPointer(path, token(next, j)), Kind: ChangeAdded, After: elem})
+			continue
+		}
+		matched[i] = true
+
+		childPath := utils.JSONPointer(path, token(next, j))
+		if prev[i].Name != elem.Name {
+			*changes = append(*changes, Change{Path: childPath, Kind: ChangeRenamed, Before: prev[i].Name, After: elem.Name})
+		}
+
+		if moved[j] {
+			*changes = append(*changes, Change{Path: childPath, Kind: ChangeReordered, Before: i, After: j})
+		}
+
+		diffElement(prev[i], elem, childPath, changes)
+	}
+
+	for i, elem := range prev {
+		if !matched[i] {
+			*changes = append(*changes, Change{Path: utils.JSONPointer(path, token(prev, i)), Kind: ChangeRemoved, Before: elem})
+		}
+	}
+}
+
+// diffElement compares two versions of the same element
+func diffElement(prev, next types.Element, path string, changes *[]Change) {
+	if prev.Optional != next.Optional {
+		*changes = append(*changes, Change{Path: path, Kind: ChangeOptional, Before: prev.Optional, After: next.Optional})
+	}
+
+	if !sameJSON(prev.Default, next.Default) {
+		*changes = append(*changes, Change{Path: path, Kind: ChangeDefault, Before: prev.Default, After: next.Default})
+	}
+
+	if prev.Indexed != next.Indexed {
+		*changes = append(*changes, Change{Path: path, Kind: ChangeIndexed, Before: prev.Indexed, After: next.Indexed})
+	}
+
+	if prev.Type != next.Type {
+		*changes = append(*changes, Change{Path: path, Kind: ChangeType, Before: describe(prev), After: describe(next)})
+		return
+	}
+
+	switch prev.Type {
+	case types.Int, types.Uint:
+		diffSize(integerSize(prev), integerSize(next), prev, next, path, changes)
+	case types.Bytes:
+		switch {
+		case prev.Size == next.Size:
+		case next.Size == 0:
+			*changes = append(*changes, Change{Path: path, Kind: ChangeFixedToDynamic, Before: describe(prev), After: describe(next)})
+		case prev.Size == 0:
+			*changes = append(*changes, Change{Path: path, Kind: ChangeDynamicToFixed, Before: describe(prev), After: describe(next)})
+		default:
+			diffSize(prev.Size, next.Size, prev, next, path, changes)
+		}
+	case types.Array:
+		switch {
+		case prev.Size == next.Size:
+		case next.Size == 0:
+			*changes = append(*changes, Change{Path: path, Kind: ChangeFixedToDynamic, Before: prev.Size, After: next.Size})
+		case prev.Size == 0:
+			*changes = append(*changes, Change{Path: path, Kind: ChangeDynamicToFixed, Before: prev.Size, After: next.Size})
+		default:
+			*changes = append(*changes, Change{Path: path, Kind: ChangeResized, Before: prev.Size, After: next.Size})
+		}
+
+		if len(prev.Children) == 1 && len(next.Children) == 1 {
+			diffElement(prev.Children[0], next.Children[0], utils.JSONPointer(path, "*"), changes)
+		}
+	case types.Object:
+		diffElements(prev.Children, next.Children, path, false, changes)
+	}
+}
+
+// diffSize reports integers or fixed-size bytes changing in size
+func diffSize(prevSize, nextSize int, prev, next types.Element, path string, changes *[]Change) {
+	switch {
+	case nextSize > prevSize:
+		*changes = append(*changes, Change{Path: path, Kind: ChangeWidened, Before: describe(prev), After: describe(next)})
+	case nextSize < prevSize:
+		*changes = append(*changes, Change{Path: path, Kind: ChangeNarrowed, Before: describe(prev), After: describe(next)})
+	}
+}
+
+// match pairs the elements of the next version, by index, with their previous version
+// Elements are paired by name when every element is named, by position otherwise
+// Leftovers of the same shape are then paired as renames, closest positions first
+func match(prev, next types.Elements) map[int]int {
+	pairs := make(map[int]int, len(next))
+	taken := make(map[int]bool, len(prev))
+
+	if named(prev) && named(next) {
+		indexes := make(map[string]int, len(prev))
+		for i, elem := range prev {
+			indexes[elem.Name] = i
+		}
+
+		for j, elem := range next {
+			if i, ok := indexes[elem.Name]; ok && !taken[i] {
+				pairs[j] = i
+				taken[i] = true
+			}
+		}
+	} else {
+		for j := range next {
+			if j < len(prev) {
+				pairs[j] = j
+				taken[j] = true
+			}
+		}
+		return pairs
+	}
+
+	for j, elem := range next {
+		if _, ok := pairs[j]; ok {
+			continue
+		}
+
+		best := -1
+		for i := range prev {
+			if taken[i] || !sameShape(prev[i], elem) {
+				continue
+			}
+
+			if best < 0 || abs(i-j) < abs(best-j) {
+				best = i
+			}
+		}
+
+		if best >= 0 {
+			pairs[j] = best
+			taken[best] = true
+		}
+	}
+
+	return pairs
+}
+
+// reordered flags the paired elements that left their relative order
+// The longest run of pairs keeping their order stays in place and the others are reported as moved
+func reordered(pairs map[int]int, length int) map[int]bool {
+	order := make([]int, 0, len(pairs))
+	for j := 0; j < length; j++ {
+		if _, ok := pairs[j]; ok {
+			order = append(order, j)
+		}
+	}
+
+	// Longest increasing subsequence of the previous indexes, in the order of the next version
+	lengths := make([]int, len(order))
+	parents := make([]int, len(order))
+	longest := -1
+	for a := range order {
+		lengths[a], parents[a] = 1, -1
+		for b := 0; b < a; b++ {
+			if pairs[order[b]] < pairs[order[a]] && lengths[b]+1 > lengths[a] {
+				lengths[a], parents[a] = lengths[b]+1, b
+			}
+		}
+
+		if longest < 0 || lengths[a] > lengths[longest] {
+			longest = a
+		}
+	}
+
+	moved := make(map[int]bool, len(order))
+	for _, j := range order {
+		moved[j] = true
+	}
+
+	for a := longest; a >= 0; a = parents[a] {
+		delete(moved, order[a])
+	}
+
+	return moved
+}
+
+// named reports whether every element has a distinct name
+func named(elements types.Elements) bool {
+	names := make(map[string]bool, len(elements))
+	for _, elem := range elements {
+		if elem.Name == "" || names[elem.Name] {
+			return false
+		}
+		names[elem.Name] = true
+	}
+	return true
+}
+
+// sameShape reports whether two elements only differ by their name
+func sameShape(a, b types.Element) bool {
+	a.Name, b.Name = "", ""
+	if !sameJSON(a.Default, b.Default) {
+		return false
+	}
+
+	a.Default, b.Default = nil, nil
+	if a.Type == types.Int || a.Type == types.Uint {
+		a.Size, b.Size = integerSize(a), integerSize(b)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// sameJSON reports whether two raw JSON values are equal once compacted
+func sameJSON(a, b json.RawMessage) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
+
+// describe returns the type name of an element, e.g. `uint256`, `bytes32[]` or `object`
+func describe(elem types.Element) string {
+	switch elem.Type {
+	case types.Int, types.Uint, types.Bytes, types.Float:
+		return utils.TypeName(elem)
+	case types.Array:
+		child := "?"
+		if len(elem.Children) == 1 {
+			child = describe(elem.Children[0])
+		}
+
+		if elem.Size > 0 {
+			return fmt.Sprintf("%s[%d]", child, elem.Size)
+		}
+		return child + "[]"
+	}
+	return string(elem.Type)
+}
+
+// rawString renders a raw JSON value of a change, `none` if it is missing
+func rawString(value any) string {
+	raw, _ := value.(json.RawMessage)
+	if raw == nil {
+		return "none"
+	}
+	return string(raw)
+}
+
+// abs returns the absolute value of an integer
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
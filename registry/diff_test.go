@@ -0,0 +1,161 @@
+package registry
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	order := types.Elements{
+		{Name: "maker", Type: types.Address},
+		{Name: "order", Type: types.Object, Children: types.Elements{
+			{Name: "amount", Type: types.Uint, Size: 128},
+			{Name: "price", Type: types.Uint},
+			{Name: "tags", Type: types.Array, Size: 2, Children: types.Elements{{Type: types.Bytes, Size: 4}}},
+		}},
+	}
+
+	type Testcase struct {
+		Name     string
+		Prev     types.Elements
+		Next     types.Elements
+		Expected []Change
+	}
+
+	testcases := []Testcase{
+		{
+			Name:     "identical",
+			Prev:     order,
+			Next:     order,
+			Expected: []Change{},
+		},
+		{
+			Name: "explicit integer size",
+			Prev: order,
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				order[1].Children[0], {Name: "price", Type: types.Uint, Size: 64}, order[1].Children[2],
+			}}},
+			Expected: []Change{},
+		},
+		{
+			Name: "added and removed fields",
+			Prev: order,
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				order[1].Children[0], order[1].Children[2], {Name: "memo", Type: types.String, Optional: true},
+			}}},
+			Expected: []Change{
+				{Path: "/1/memo", Kind: ChangeAdded, After: types.Element{Name: "memo", Type: types.String, Optional: true}},
+				{Path: "/1/price", Kind: ChangeRemoved, Before: types.Element{Name: "price", Type: types.Uint}},
+			},
+		},
+		{
+			Name: "renamed field",
+			Prev: order,
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				{Name: "quantity", Type: types.Uint, Size: 128}, order[1].Children[1], order[1].Children[2],
+			}}},
+			Expected: []Change{
+				{Path: "/1/quantity", Kind: ChangeRenamed, Before: "amount", After: "quantity"},
+			},
+		},
+		{
+			Name: "reordered fields",
+			Prev: order,
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				order[1].Children[2], order[1].Children[0], order[1].Children[1],
+			}}},
+			Expected: []Change{
+				{Path: "/1/tags", Kind: ChangeReordered, Before: 2, After: 0},
+			},
+		},
+		{
+			Name: "sizes",
+			Prev: order,
+			Next: types.Elements{order[0], {Name: "order", Type: types.Object, Children: types.Elements{
+				{Name: "amount", Type: types.Uint, Size: 256},
+				{Name: "price", Type: types.Uint, Size: 32},
+				{Name: "tags", Type: types.Array, Children: types.Elements{{Type: types.Bytes, Size: 8}}},
+			}}},
+			Expected: []Change{
+				{Path: "/1/amount", Kind: ChangeWidened, Before: "uint128", After: "uint256"},
+				{Path: "/1/price", Kind: ChangeNarrowed, Before: "uint64", After: "uint32"},
+				{Path: "/1/tags", Kind: ChangeFixedToDynamic, Before: 2, After: 0},
+				{Path: "/1/tags/*", Kind: ChangeWidened, Before: "bytes4", After: "bytes8"},
+			},
+		},
+		{
+			Name: "array and bytes sizes",
+			Prev: types.Elements{
+				{Name: "ids", Type: types.Array, Children: types.Elements{{Type: types.Uint, Size: 8}}},
+				{Name: "slots", Type: types.Array, Size: 3, Children: types.Elements{{Type: types.Bytes, Size: 32}}},
+				{Name: "data", Type: types.Bytes},
+			},
+			Next: types.Elements{
+				{Name: "ids", Type: types.Array, Size: 4, Children: types.Elements{{Type: types.Uint, Size: 8}}},
+				{Name: "slots", Type: types.Array, Size: 5, Children: types.Elements{{Type: types.Bytes}}},
+				{Name: "data", Type: types.Bytes, Size: 32},
+			},
+			Expected: []Change{
+				{Path: "/0", Kind: ChangeDynamicToFixed, Before: 0, After: 4},
+				{Path: "/1", Kind: ChangeResized, Before: 3, After: 5},
+				{Path: "/1/*", Kind: ChangeFixedToDynamic, Before: "bytes32", After: "bytes"},
+				{Path: "/2", Kind: ChangeDynamicToFixed, Before: "bytes", After: "bytes32"},
+			},
+		},
+		{
+			Name: "flags and type",
+			Prev: types.Elements{
+				{Name: "from", Type: types.Address, Indexed: true},
+				{Name: "memo", Type: types.String},
+				{Name: "nonce", Type: types.Uint, Default: json.RawMessage(`0`)},
+			},
+			Next: types.Elements{
+				{Name: "from", Type: types.Bytes, Size: 20},
+				{Name: "memo", Type: types.String, Optional: true},
+				{Name: "nonce", Type: types.Uint, Default: json.RawMessage(`1`)},
+			},
+			Expected: []Change{
+				{Path: "/0", Kind: ChangeIndexed, Before: true, After: false},
+				{Path: "/0", Kind: ChangeType, Before: "address", After: "bytes20"},
+				{Path: "/1", Kind: ChangeOptional, Before: false, After: true},
+				{Path: "/2", Kind: ChangeDefault, Before: json.RawMessage(`0`), After: json.RawMessage(`1`)},
+			},
+		},
+		{
+			Name: "unnamed elements by position",
+			Prev: types.Elements{{Type: types.Address}, {Type: types.Uint, Size: 256}, {Type: types.Bool}},
+			Next: types.Elements{{Type: types.Address}, {Type: types.Int, Size: 256}},
+			Expected: []Change{
+				{Path: "/1", Kind: ChangeType, Before: "uint256", After: "int256"},
+				{Path: "/2", Kind: ChangeRemoved, Before: types.Element{Type: types.Bool}},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, Diff(tc.Prev, tc.Next))
+		})
+	}
+}
+
+func TestChange_String(t *testing.T) {
+	changes := Diff(
+		types.Elements{{Name: "amount", Type: types.Uint, Size: 128}, {Name: "tags", Type: types.Array, Children: types.Elements{{Type: types.Bytes, Size: 4}}}},
+		types.Elements{{Name: "amount", Type: types.Uint, Size: 256}, {Name: "memo", Type: types.String, Default: json.RawMessage(`""`)}},
+	)
+
+	lines := make([]string, len(changes))
+	for i, change := range changes {
+		lines[i] = change.String()
+	}
+
+	assert.Equal(t, []string{
+		"/0: widened uint128 -> uint256",
+		"/1: added string",
+		"/1: removed bytes4[]",
+	}, lines)
+}
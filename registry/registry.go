@@ -0,0 +1,151 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ideatru/welder/types"
+)
+
+// Schema is a version of a named schema as kept by a Store
+type Schema struct {
+	// Name identifies the schema across its versions, e.g. `transfer`
+	Name string `json:"name"`
+	// Version starts at 1 and increases by one with every registered change
+	Version int `json:"version"`
+	// Fingerprint identifies the elements, see Fingerprint
+	Fingerprint string `json:"fingerprint"`
+	// Elements is the schema itself
+	Elements types.Elements `json:"elements"`
+}
+
+// Option contains configuration options for Register
+type Option struct {
+	// Require rejects changes whose compatibility with the latest version doesn't satisfy it
+	// Breaking by default, which accepts every change
+	Require Compatibility
+}
+
+// Registry keeps named schemas with their history and checks changes between versions
+type Registry struct {
+	store Store
+}
+
+// New creates a Registry backed by the store
+func New(store Store) *Registry {
+	return &Registry{store: store}
+}
+
+// Fingerprint returns the canonical hash of the elements, see types.Hash
+// Schemas authored differently but describing the same payloads and ABI share their fingerprint
+func Fingerprint(elements types.Elements) (string, error) {
+	return types.Hash(elements)
+}
+
+// Register stores the elements as the next version of the named schema and returns it
+// with its compatibility with the previous version, Compatible for the first one
+// Registering the same elements as the latest version returns it without a new version
+// Returns an error if the change doesn't satisfy the required compatibility
+func (r *Registry) Register(name string, elements types.Elements, opts ...Option) (Schema, Compatibility, error) {
+	var opt Option
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	if !isValidName(name) {
+		return Schema{}, Breaking, fmt.Errorf("invalid schema name %q", name)
+	}
+
+	fingerprint, err := Fingerprint(elements)
+	if err != nil {
+		return Schema{}, Breaking, err
+	}
+
+	schema := Schema{Name: name, Version: 1, Fingerprint: fingerprint, Elements: elements}
+	compatibility := Compatible
+
+	latest, err := r.Latest(name)
+	switch {
+	case errors.Is(err, ErrNotFound):
+	case err != nil:
+		return Schema{}, Breaking, err
+	case latest.Fingerprint == fingerprint:
+		return latest, Compatible, nil
+	default:
+		schema.Version = latest.Version + 1
+		compatibility = Classify(latest.Elements, elements)
+		if !compatibility.Satisfies(opt.Require) {
+			return Schema{}, compatibility, fmt.Errorf("%s v%d is a %s change, %s is required", name, schema.Version, compatibility, opt.Require)
+		}
+	}
+
+	if err := r.store.Save(schema); err != nil {
+		return Schema{}, Breaking, err
+	}
+
+	return schema, compatibility, nil
+}
+
+// Check classifies the change from the latest version of the named schema to the elements without registering it
+func (r *Registry) Check(name string, elements types.Elements) (Compatibility, error) {
+	latest, err := r.Latest(name)
+	if err != nil {
+		return Breaking, err
+	}
+
+	return Classify(latest.Elements, elements), nil
+}
+
+// Get returns a version of the named schema
+func (r *Registry) Get(name string, version int) (Schema, error) {
+	return r.store.Load(name, version)
+}
+
+// Latest returns the highest version of the named schema
+func (r *Registry) Latest(name string) (Schema, error) {
+	versions, err := r.store.Versions(name)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	if len(versions) == 0 {
+		return Schema{}, fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+
+	return r.store.Load(name, versions[len(versions)-1])
+}
+
+// Compare classifies the change between two versions of the named schema
+func (r *Registry) Compare(name string, from, to int) (Compatibility, error) {
+	prev, err := r.store.Load(name, from)
+	if err != nil {
+		return Breaking, err
+	}
+
+	next, err := r.store.Load(name, to)
+	if err != nil {
+		return Breaking, err
+	}
+
+	return Classify(prev.Elements, next.Elements), nil
+}
+
+// Diff lists the differences between two versions of the named schema
+func (r *Registry) Diff(name string, from, to int) ([]Change, error) {
+	prev, err := r.store.Load(name, from)
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := r.store.Load(name, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return Diff(prev.Elements, next.Elements), nil
+}
+
+// Names lists the registered schemas
+func (r *Registry) Names() ([]string, error) {
+	return r.store.Names()
+}
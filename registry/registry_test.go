@@ -0,0 +1,111 @@
+package registry
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	r := New(NewMemoryStore())
+
+	v1 := types.Elements{
+		{Name: "to", Type: types.Address},
+		{Name: "amount", Type: types.Uint, Size: 128},
+	}
+
+	schema, compatibility, err := r.Register("transfer", v1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, schema.Version)
+	assert.Equal(t, Compatible, compatibility)
+
+	t.Run("same elements", func(t *testing.T) {
+		schema, compatibility, err := r.Register("transfer", types.Elements{
+			{Name: "to", Type: types.Address},
+			{Name: "amount", Type: types.Uint, Size: 128},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, schema.Version)
+		assert.Equal(t, Compatible, compatibility)
+	})
+
+	t.Run("same elements with type aliases", func(t *testing.T) {
+		schema, compatibility, err := r.Register("transfer", types.Elements{
+			{Name: "to", Type: "address"},
+			{Name: "amount", Type: "uint128"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, schema.Version)
+		assert.Equal(t, Compatible, compatibility)
+	})
+
+	t.Run("required compatibility", func(t *testing.T) {
+		widened := types.Elements{
+			{Name: "to", Type: types.Address},
+			{Name: "amount", Type: types.Uint, Size: 256},
+		}
+
+		_, compatibility, err := r.Register("transfer", widened, Option{Require: ABICompatible})
+		assert.EqualError(t, err, "transfer v2 is a json-compatible change, abi-compatible is required")
+		assert.Equal(t, JSONCompatible, compatibility)
+
+		compatibility, err = r.Check("transfer", widened)
+		assert.NoError(t, err)
+		assert.Equal(t, JSONCompatible, compatibility)
+
+		schema, compatibility, err := r.Register("transfer", widened, Option{Require: JSONCompatible})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, schema.Version)
+		assert.Equal(t, JSONCompatible, compatibility)
+	})
+
+	t.Run("history", func(t *testing.T) {
+		latest, err := r.Latest("transfer")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, latest.Version)
+
+		first, err := r.Get("transfer", 1)
+		assert.NoError(t, err)
+		assert.Equal(t, v1, first.Elements)
+
+		compatibility, err := r.Compare("transfer", 2, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, Breaking, compatibility)
+
+		changes, err := r.Diff("transfer", 1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []Change{{Path: "/1", Kind: ChangeWidened, Before: "uint128", After: "uint256"}}, changes)
+
+		names, err := r.Names()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"transfer"}, names)
+	})
+
+	t.Run("unknown schema", func(t *testing.T) {
+		_, err := r.Check("approve", v1)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestFingerprint(t *testing.T) {
+	a, err := Fingerprint(types.Elements{{Name: "ids", Type: types.Array, Default: json.RawMessage(`[1, 2]`), Children: types.Elements{{Type: types.Uint}}}})
+	assert.NoError(t, err)
+
+	b, err := Fingerprint(types.Elements{{Name: "ids", Type: types.Array, Default: json.RawMessage(`[1,2]`), Children: types.Elements{{Type: types.Uint}}}})
+	assert.NoError(t, err)
+	assert.Equal(t, a, b)
+	assert.Len(t, a, 64)
+
+	b, err = Fingerprint(types.Elements{{Name: "ids", Type: "uint64[]", Default: json.RawMessage(`[1,2]`)}})
+	assert.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	c, err := Fingerprint(types.Elements{{Name: "ids", Type: types.Array, Default: json.RawMessage(`[1,3]`), Children: types.Elements{{Type: types.Uint}}}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, a, c)
+
+	_, err = Fingerprint(types.Elements{{Name: "ids", Type: types.Uint, Default: json.RawMessage(`[1,`)}})
+	assert.Error(t, err)
+}
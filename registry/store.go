@@ -0,0 +1,250 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrNotFound is returned when a schema name or version is not in the store
+	ErrNotFound = errors.New("schema not found")
+	// ErrExists is returned when saving a version that is already stored, versions are immutable
+	ErrExists = errors.New("schema version already exists")
+)
+
+// Store persists versioned schemas
+// Implementations must treat versions as immutable and return ErrNotFound for unknown schemas
+type Store interface {
+	// Save stores a new version of a schema, failing with ErrExists if the version is taken
+	Save(schema Schema) error
+	// Load returns a version of a named schema
+	Load(name string, version int) (Schema, error)
+	// Versions lists the stored versions of a named schema in ascending order
+	Versions(name string) ([]int, error)
+	// Names lists the names of the stored schemas in ascending order
+	Names() ([]string, error)
+}
+
+// FileStore is a Store keeping every version in its own JSON file, `<dir>/<name>/v<version>.json`
+// The files are meant to be committed next to the contracts they describe
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a FileStore rooted at the directory, which is created on the first save
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// Save writes the schema into a new file
+func (s *FileStore) Save(schema Schema) error {
+	if !isValidName(schema.Name) {
+		return fmt.Errorf("invalid schema name %q", schema.Name)
+	}
+
+	dir := filepath.Join(s.dir, schema.Name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(s.path(schema.Name, schema.Version), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%s v%d: %w", schema.Name, schema.Version, ErrExists)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Load reads the file of a version
+func (s *FileStore) Load(name string, version int) (Schema, error) {
+	if !isValidName(name) {
+		return Schema{}, fmt.Errorf("invalid schema name %q", name)
+	}
+
+	data, err := os.ReadFile(s.path(name, version))
+	if errors.Is(err, os.ErrNotExist) {
+		return Schema{}, fmt.Errorf("%s v%d: %w", name, version, ErrNotFound)
+	}
+
+	if err != nil {
+		return Schema{}, err
+	}
+
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return Schema{}, fmt.Errorf("failed to decode %s v%d: %w", name, version, err)
+	}
+
+	return schema, nil
+}
+
+// Versions lists the version files of a schema, ignoring unrelated files
+func (s *FileStore) Versions(name string) ([]int, error) {
+	if !isValidName(name) {
+		return nil, fmt.Errorf("invalid schema name %q", name)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(s.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		base, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() || !strings.HasPrefix(base, "v") {
+			continue
+		}
+
+		version, err := strconv.Atoi(base[1:])
+		if err != nil || version < 1 {
+			continue
+		}
+		versions = append(versions, version)
+	}
+
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+
+	sort.Ints(versions)
+	return versions, nil
+}
+
+// Names lists the schema directories
+func (s *FileStore) Names() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() && isValidName(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+
+	return names, nil
+}
+
+// path returns the file of a version
+func (s *FileStore) path(name string, version int) string {
+	return filepath.Join(s.dir, name, fmt.Sprintf("v%d.json", version))
+}
+
+// MemoryStore is a Store keeping schemas in memory, for tests and short-lived registries
+type MemoryStore struct {
+	mu      sync.RWMutex
+	schemas map[string]map[int]Schema
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{schemas: make(map[string]map[int]Schema)}
+}
+
+// Save keeps the schema in memory
+func (s *MemoryStore) Save(schema Schema) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	versions, ok := s.schemas[schema.Name]
+	if !ok {
+		versions = make(map[int]Schema)
+		s.schemas[schema.Name] = versions
+	}
+
+	if _, ok := versions[schema.Version]; ok {
+		return fmt.Errorf("%s v%d: %w", schema.Name, schema.Version, ErrExists)
+	}
+
+	versions[schema.Version] = schema
+	return nil
+}
+
+// Load returns a version kept in memory
+func (s *MemoryStore) Load(name string, version int) (Schema, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	schema, ok := s.schemas[name][version]
+	if !ok {
+		return Schema{}, fmt.Errorf("%s v%d: %w", name, version, ErrNotFound)
+	}
+
+	return schema, nil
+}
+
+// Versions lists the versions kept in memory
+func (s *MemoryStore) Versions(name string) ([]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.schemas[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+
+	versions := make([]int, 0, len(stored))
+	for version := range stored {
+		versions = append(versions, version)
+	}
+
+	sort.Ints(versions)
+	return versions, nil
+}
+
+// Names lists the names kept in memory
+func (s *MemoryStore) Names() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.schemas))
+	for name := range s.schemas {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// isValidName reports whether a schema name is safe to use as a path segment
+func isValidName(name string) bool {
+	if name == "" || name == "." || name == ".." {
+		return false
+	}
+
+	return !strings.ContainsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.')
+	})
+}
@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) Store{
+		"file":   func(t *testing.T) Store { return NewFileStore(t.TempDir()) },
+		"memory": func(t *testing.T) Store { return NewMemoryStore() },
+	}
+
+	for name, create := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := create(t)
+
+			names, err := store.Names()
+			assert.NoError(t, err)
+			assert.Empty(t, names)
+
+			_, err = store.Versions("transfer")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			v1 := Schema{Name: "transfer", Version: 1, Fingerprint: "a", Elements: types.Elements{{Name: "to", Type: types.Address}}}
+			v2 := Schema{Name: "transfer", Version: 2, Fingerprint: "b", Elements: types.Elements{{Name: "to", Type: types.Address}, {Name: "amount", Type: types.Uint, Size: 256}}}
+			v10 := Schema{Name: "transfer", Version: 10, Fingerprint: "c", Elements: types.Elements{}}
+			approve := Schema{Name: "approve", Version: 1, Fingerprint: "d", Elements: types.Elements{{Name: "spender", Type: types.Address}}}
+
+			for _, schema := range []Schema{v2, v1, v10, approve} {
+				assert.NoError(t, store.Save(schema))
+			}
+
+			assert.ErrorIs(t, store.Save(v1), ErrExists)
+
+			versions, err := store.Versions("transfer")
+			assert.NoError(t, err)
+			assert.Equal(t, []int{1, 2, 10}, versions)
+
+			names, err = store.Names()
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"approve", "transfer"}, names)
+
+			loaded, err := store.Load("transfer", 2)
+			assert.NoError(t, err)
+			assert.Equal(t, v2, loaded)
+
+			_, err = store.Load("transfer", 3)
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+
+	t.Run("file layout", func(t *testing.T) {
+		dir := t.TempDir()
+		store := NewFileStore(dir)
+
+		assert.NoError(t, store.Save(Schema{Name: "transfer", Version: 1, Elements: types.Elements{}}))
+		assert.FileExists(t, filepath.Join(dir, "transfer", "v1.json"))
+
+		// Unrelated files are ignored
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "transfer", "README.md"), nil, 0o644))
+		versions, err := store.Versions("transfer")
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1}, versions)
+
+		assert.ErrorContains(t, store.Save(Schema{Name: "../escape", Version: 1}), `invalid schema name "../escape"`)
+		_, err = store.Load("..", 1)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,19 @@
+package welder
+
+import (
+	"io"
+
+	"github.com/ideatru/welder/ether"
+	"github.com/ideatru/welder/types"
+)
+
+// NewStreamEncoder creates an encoder packing JSON payloads read from the reader straight into ABI encoded data.
+// It skips the Go values built by Weld, which suits large payloads such as bulk multicalls.
+// Returns an error if the schema cannot be serialized with the configuration of the welder.
+func (w *EthereumWelder) NewStreamEncoder(schema types.Elements, r io.Reader) (*ether.StreamEncoder, error) {
+	if _, err := w.Serialize(schema); err != nil {
+		return nil, err
+	}
+
+	return ether.NewStreamEncoder(schema, r), nil
+}
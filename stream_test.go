@@ -0,0 +1,125 @@
+package welder_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/ideatru/welder"
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// bulkSchema is a multicall taking a list of calls
+var bulkSchema = types.Elements{
+	{Type: types.Array, Children: types.Elements{
+		{Type: types.Object, Children: types.Elements{
+			{Type: types.Address, Name: "target"},
+			{Type: types.Bool, Name: "allowFailure"},
+			{Type: types.Bytes, Name: "callData"},
+		}},
+	}},
+}
+
+// bulkPayload holds ten thousand calls of the multicall
+var bulkPayload = func() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`[[`)
+	for i := 0; i < 10000; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `{"target": "0x%040x", "allowFailure": %t, "callData": "0x70a08231%064x"}`, i, i%2 == 0, i)
+	}
+	buf.WriteString(`]]`)
+	return buf.Bytes()
+}()
+
+func TestEthereumWelder_NewStreamEncoder(t *testing.T) {
+	w := welder.NewEthereum()
+
+	for _, tc := range []struct {
+		Name    string
+		Schema  types.Elements
+		Payload []byte
+	}{
+		{Name: "benchmark", Schema: benchmarkSchema, Payload: benchmarkPayload},
+		{Name: "bulk", Schema: bulkSchema, Payload: bulkPayload},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			args, err := w.Serialize(tc.Schema)
+			assert.NoError(t, err)
+
+			params, err := w.Weld(tc.Schema, tc.Payload)
+			assert.NoError(t, err)
+
+			expected, err := args.Encode(params...)
+			assert.NoError(t, err)
+
+			encoder, err := w.NewStreamEncoder(tc.Schema, bytes.NewReader(tc.Payload))
+			assert.NoError(t, err)
+
+			actual, err := encoder.Encode()
+			assert.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	t.Run("invalid-schema", func(t *testing.T) {
+		_, err := w.NewStreamEncoder(types.Elements{{Type: types.Array}}, bytes.NewReader(benchmarkPayload))
+		assert.Error(t, err)
+	})
+}
+
+func BenchmarkStreamEncode(b *testing.B) {
+	w := welder.NewEthereum()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		encoder, err := w.NewStreamEncoder(benchmarkSchema, bytes.NewReader(benchmarkPayload))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if _, err := encoder.Encode(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBulkWeldEncode(b *testing.B) {
+	w := welder.NewEthereum()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		args, err := w.Serialize(bulkSchema)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		params, err := w.Weld(bulkSchema, bulkPayload)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if _, err := args.Encode(params...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBulkStreamEncode(b *testing.B) {
+	w := welder.NewEthereum()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		encoder, err := w.NewStreamEncoder(bulkSchema, bytes.NewReader(bulkPayload))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if _, err := encoder.Encode(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// defaultIntegerSize is the size of integers declared without one
+const defaultIntegerSize = 64
+
+// Canonical returns a copy of the elements in their canonical form, so that schemas authored
+// differently but describing the same payloads and ABI compare equal
+// Type names such as `uint256` or `tuple` are resolved, integers without a size get 64 bits,
+// sizes of strings, booleans, addresses and objects are dropped since they have no meaning,
+// names of array items are dropped since neither payloads nor the ABI use them,
+// defaults are compacted and empty children become nil
+// Returns an error if a type is unknown or a default is not valid JSON
+func Canonical(elements Elements) (Elements, error) {
+	canonical := make(Elements, len(elements))
+	for i, elem := range elements {
+		c, err := canonicalElement(elem)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		canonical[i] = c
+	}
+
+	return canonical, nil
+}
+
+// Hash returns the hex encoded SHA-256 of the JSON encoding of the canonical elements
+// Equal schemas have the same hash, whatever the layout of their source
+func Hash(elements Elements) (string, error) {
+	canonical, err := Canonical(elements)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(canonical)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Equal reports whether both schemas have the same canonical form
+// Schemas that cannot be canonicalized are only equal to themselves, field by field
+func Equal(a, b Elements) bool {
+	ca, errA := Canonical(a)
+	cb, errB := Canonical(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+
+	return reflect.DeepEqual(ca, cb)
+}
+
+// canonicalElement returns the canonical form of a single element and its children
+func canonicalElement(elem Element) (Element, error) {
+	if !isCanonicalType(elem.Type) {
+		normalized, err := normalizeType(elem)
+		if err != nil {
+			return Element{}, err
+		}
+		elem = normalized
+	}
+
+	switch elem.Type {
+	case Int, Uint:
+		if elem.Size == 0 {
+			elem.Size = defaultIntegerSize
+		}
+	case String, Bool, Address, Object:
+		elem.Size = 0
+	}
+
+	if elem.Default != nil {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, elem.Default); err != nil {
+			return Element{}, fmt.Errorf("invalid default of %q: %w", elem.Name, err)
+		}
+		elem.Default = buf.Bytes()
+	}
+
+	if len(elem.Children) == 0 {
+		elem.Children = nil
+		return elem, nil
+	}
+
+	children, err := Canonical(elem.Children)
+	if err != nil {
+		return Element{}, err
+	}
+
+	if elem.Type == Array {
+		for i := range children {
+			children[i].Name = ""
+		}
+	}
+
+	elem.Children = children
+	return elem, nil
+}
+
+// isCanonicalType reports whether the type is one of the ElementType constants
+func isCanonicalType(ty ElementType) bool {
+	switch ty {
+	case Int, Uint, Float, String, Bytes, Address, Bool, Array, Object:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ideatru/welder/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonical(t *testing.T) {
+	type Testcase struct {
+		Name     string
+		Input    types.Elements
+		Expected types.Elements
+	}
+
+	testcases := []Testcase{
+		{
+			Name:     "integer sizes",
+			Input:    types.Elements{{Type: types.Int}, {Type: types.Uint, Size: 256}, {Type: types.Bytes}},
+			Expected: types.Elements{{Type: types.Int, Size: 64}, {Type: types.Uint, Size: 256}, {Type: types.Bytes}},
+		},
+		{
+			Name: "type aliases",
+			Input: types.Elements{
+				{Name: "amount", Type: "uint256"},
+				{Name: "ok", Type: "bool"},
+				{Name: "ids", Type: "uint8[2]"},
+				{Name: "pair", Type: "tuple", Children: types.Elements{{Name: "key", Type: "bytes32"}}},
+			},
+			Expected: types.Elements{
+				{Name: "amount", Type: types.Uint, Size: 256},
+				{Name: "ok", Type: types.Bool},
+				{Name: "ids", Type: types.Array, Size: 2, Children: types.Elements{{Type: types.Uint, Size: 8}}},
+				{Name: "pair", Type: types.Object, Children: types.Elements{{Name: "key", Type: types.Bytes, Size: 32}}},
+			},
+		},
+		{
+			Name: "meaningless sizes",
+			Input: types.Elements{
+				{Type: types.String, Size: 4},
+				{Type: types.Bool, Size: 1},
+				{Type: "address", Size: 20},
+				{Type: types.Object, Size: 2, Children: types.Elements{{Name: "a", Type: types.Bool}}},
+			},
+			Expected: types.Elements{
+				{Type: types.String},
+				{Type: types.Bool},
+				{Type: types.Address},
+				{Type: types.Object, Children: types.Elements{{Name: "a", Type: types.Bool}}},
+			},
+		},
+		{
+			Name: "array items and children",
+			Input: types.Elements{
+				{Type: types.Array, Children: types.Elements{{Name: "item", Type: types.Address, Children: types.Elements{}}}},
+			},
+			Expected: types.Elements{
+				{Type: types.Array, Children: types.Elements{{Type: types.Address}}},
+			},
+		},
+		{
+			Name:     "defaults",
+			Input:    types.Elements{{Type: types.Array, Default: json.RawMessage("[ 1,\n 2 ]"), Children: types.Elements{{Type: types.Uint}}}},
+			Expected: types.Elements{{Type: types.Array, Default: json.RawMessage("[1,2]"), Children: types.Elements{{Type: types.Uint, Size: 64}}}},
+		},
+		{
+			Name:     "empty",
+			Input:    nil,
+			Expected: types.Elements{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			canonical, err := types.Canonical(tc.Input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, canonical)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := types.Canonical(types.Elements{{Type: "uint7x"}})
+		assert.ErrorContains(t, err, `unknown type "uint7x"`)
+
+		_, err = types.Canonical(types.Elements{{Type: "uint8", Size: 16}})
+		assert.ErrorContains(t, err, `size 16 conflicts with type "uint8"`)
+
+		_, err = types.Canonical(types.Elements{{Type: types.Object, Children: types.Elements{{Name: "a", Type: types.Uint, Default: json.RawMessage("{")}}}})
+		assert.ErrorContains(t, err, `element 0: element 0: invalid default of "a"`)
+	})
+}
+
+func TestEqualAndHash(t *testing.T) {
+	authored := types.Elements{
+		{Name: "to", Type: types.Address},
+		{Name: "amounts", Type: types.Array, Children: types.Elements{{Name: "amount", Type: types.Uint}}},
+	}
+	parsed := types.Elements{
+		{Name: "to", Type: "address"},
+		{Name: "amounts", Type: "uint64[]"},
+	}
+
+	assert.True(t, types.Equal(authored, parsed))
+
+	a, err := types.Hash(authored)
+	assert.NoError(t, err)
+	b, err := types.Hash(parsed)
+	assert.NoError(t, err)
+	assert.Equal(t, a, b)
+	assert.Len(t, a, 64)
+
+	renamed := types.Elements{authored[0], {Name: "values", Type: "uint64[]"}}
+	assert.False(t, types.Equal(authored, renamed))
+
+	c, err := types.Hash(renamed)
+	assert.NoError(t, err)
+	assert.NotEqual(t, a, c)
+
+	invalid := types.Elements{{Type: "uint7x"}}
+	assert.True(t, types.Equal(invalid, invalid))
+	assert.False(t, types.Equal(invalid, authored))
+}
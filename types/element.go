@@ -1,15 +1,41 @@
 package types
 
+import "encoding/json"
+
 // Elements is a slice of Element.
 type Elements []Element
 
 // Element represents a schema element with a type, optional name, nullability flag,
 // and optional child elements for array and object types.
+// Optional elements may be missing or null in a payload and are built as pointer types.
+// Default holds the JSON value injected when a payload omits the element.
+// Indexed marks event inputs that are emitted as topics instead of log data.
 type Element struct {
-	Name     string      `json:"name"`
-	Type     ElementType `json:"type"`
-	Size     int         `json:"size"`
-	Children Elements    `json:"children"`
+	Name     string          `json:"name"`
+	Type     ElementType     `json:"type"`
+	Size     int             `json:"size"`
+	Optional bool            `json:"optional,omitempty"`
+	Indexed  bool            `json:"indexed,omitempty"`
+	Default  json.RawMessage `json:"default,omitempty"`
+	Children Elements        `json:"children"`
+}
+
+// Clone returns a deep copy of the elements, children and defaults included.
+func (e Elements) Clone() Elements {
+	if e == nil {
+		return nil
+	}
+
+	clone := make(Elements, len(e))
+	for i, elem := range e {
+		clone[i] = elem
+		if elem.Default != nil {
+			clone[i].Default = append(json.RawMessage(nil), elem.Default...)
+		}
+		clone[i].Children = elem.Children.Clone()
+	}
+
+	return clone
 }
 
 type Parser[T any] interface {
@@ -92,6 +92,90 @@ func TestUnmarshalToElements(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "solidity-aliases",
+			Input: `[
+				{"type": "uint256"},
+				{"type": "int128"},
+				{"type": "bytes32"},
+				{"type": "integer"},
+				{"type": "bool"},
+				{"type": "address[]"},
+				{"type": "uint8[3][]"},
+				{"type": "uint", "size": 160}
+			]`,
+			Expected: types.Elements{
+				{Type: types.Uint, Size: 256},
+				{Type: types.Int, Size: 128},
+				{Type: types.Bytes, Size: 32},
+				{Type: types.Int},
+				{Type: types.Bool},
+				{Type: types.Array, Children: types.Elements{{Type: types.Address}}},
+				{Type: types.Array, Children: types.Elements{{Type: types.Array, Size: 3, Children: types.Elements{{Type: types.Uint, Size: 8}}}}},
+				{Type: types.Uint, Size: 160},
+			},
+		},
+		{
+			Name: "tuple",
+			Input: `[
+				{
+					"name": "order",
+					"type": "tuple",
+					"children": [
+						{"name": "owner", "type": "address"},
+						{"name": "amounts", "type": "uint256[]"}
+					]
+				},
+				{
+					"name": "orders",
+					"type": "tuple[]",
+					"children": [
+						{"name": "owner", "type": "address"}
+					]
+				}
+			]`,
+			Expected: types.Elements{
+				{
+					Name: "order",
+					Type: types.Object,
+					Children: types.Elements{
+						{Name: "owner", Type: types.Address},
+						{Name: "amounts", Type: types.Array, Children: types.Elements{{Type: types.Uint, Size: 256}}},
+					},
+				},
+				{
+					Name: "orders",
+					Type: types.Array,
+					Children: types.Elements{
+						{Type: types.Object, Children: types.Elements{{Name: "owner", Type: types.Address}}},
+					},
+				},
+			},
+		},
+		{
+			Name: "unsized-integer-aliases",
+			Input: `[
+				{"type": "uint"},
+				{"type": "int"},
+				"uint[]",
+				{"type": "int", "size": 32}
+			]`,
+			Expected: types.Elements{
+				{Type: types.Uint},
+				{Type: types.Int},
+				{Type: types.Array, Children: types.Elements{{Type: types.Uint}}},
+				{Type: types.Int, Size: 32},
+			},
+		},
+		{
+			Name:  "type-name-shorthand",
+			Input: `["string", "bytes", "int64[2]"]`,
+			Expected: types.Elements{
+				{Type: types.String},
+				{Type: types.Bytes},
+				{Type: types.Array, Size: 2, Children: types.Elements{{Type: types.Int, Size: 64}}},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -105,3 +189,53 @@ func TestUnmarshalToElements(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalToElementsInvalid(t *testing.T) {
+	testcases := map[string]string{
+		"unknown-type":    `[{"type": "decimal"}]`,
+		"invalid-size":    `[{"type": "uint0"}]`,
+		"invalid-array":   `[{"type": "uint256[x]"}]`,
+		"size-conflict":   `[{"type": "uint256", "size": 8}]`,
+		"unknown-string":  `["hash"]`,
+		"array-size":      `[{"type": "uint256[]", "size": 3}]`,
+		"scalar-children": `[{"type": "uint256", "children": [{"type": "bool"}]}]`,
+		"alias-children":  `[{"type": "address", "children": [{"type": "bool"}]}]`,
+	}
+
+	for name, input := range testcases {
+		t.Run(name, func(t *testing.T) {
+			var elements types.Elements
+			assert.Error(t, json.Unmarshal([]byte(input), &elements))
+		})
+	}
+}
+
+func TestMarshalElements(t *testing.T) {
+	elements := types.Elements{
+		{Name: "to", Type: types.Address},
+		{Name: "memo", Type: types.String, Optional: true},
+	}
+
+	data, err := json.Marshal(elements)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"name": "to", "type": "address", "size": 0, "children": null},
+		{"name": "memo", "type": "string", "size": 0, "optional": true, "children": null}
+	]`, string(data))
+}
+
+func TestElements_Clone(t *testing.T) {
+	elements := types.Elements{
+		{Name: "limit", Type: types.Uint, Size: 8, Default: json.RawMessage(`7`)},
+		{Name: "tags", Type: types.Array, Children: types.Elements{{Type: types.Bytes, Size: 4}}},
+	}
+
+	clone := elements.Clone()
+	assert.Equal(t, elements, clone)
+
+	clone[0].Default[0] = '9'
+	clone[1].Children[0].Size = 2
+	assert.Equal(t, json.RawMessage(`7`), elements[0].Default)
+	assert.Equal(t, 4, elements[1].Children[0].Size)
+	assert.Nil(t, types.Elements(nil).Clone())
+}
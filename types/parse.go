@@ -0,0 +1,159 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// typeAliases maps human and Solidity-style type names onto element types
+var typeAliases = map[string]ElementType{
+	"int":     Int,
+	"integer": Int,
+	"number":  Int,
+	"uint":    Uint,
+	"float":   Float,
+	"string":  String,
+	"bytes":   Bytes,
+	"address": Address,
+	"bool":    Bool,
+	"boolean": Bool,
+	"array":   Array,
+	"object":  Object,
+	"tuple":   Object,
+}
+
+// sizedTypes lists the type prefixes that accept a size suffix, e.g. `uint256` or `bytes32`
+var sizedTypes = []ElementType{Uint, Int, Bytes, Float}
+
+// ParseType parses a type name such as `uint256`, `bytes32`, `address[]` or `number` into an Element
+// Array suffixes are unwrapped into nested Array elements, the outermost suffix being the last one
+// Returns an error if the type name is unknown or malformed
+func ParseType(name string) (Element, error) {
+	return parseType(strings.TrimSpace(name), nil)
+}
+
+// parseType parses a type name, attaching children to the innermost tuple if any
+func parseType(name string, children Elements) (Element, error) {
+	if strings.HasSuffix(name, "]") {
+		open := strings.LastIndex(name, "[")
+		if open <= 0 {
+			return Element{}, fmt.Errorf("invalid array type %q", name)
+		}
+
+		child, err := parseType(name[:open], children)
+		if err != nil {
+			return Element{}, err
+		}
+
+		elem := Element{Type: Array, Children: Elements{child}}
+		if length := name[open+1 : len(name)-1]; length != "" {
+			size, err := strconv.Atoi(length)
+			if err != nil || size <= 0 {
+				return Element{}, fmt.Errorf("invalid array length in %q", name)
+			}
+			elem.Size = size
+		}
+
+		return elem, nil
+	}
+
+	lower := strings.ToLower(name)
+	if ty, ok := typeAliases[lower]; ok {
+		if len(children) > 0 && ty != Object && ty != Array {
+			return Element{}, fmt.Errorf("type %q does not take children", name)
+		}
+		return Element{Type: ty, Children: children}, nil
+	}
+
+	if len(children) > 0 {
+		return Element{}, fmt.Errorf("type %q does not take children", name)
+	}
+
+	if lower == "byte" {
+		return Element{Type: Bytes, Size: 1}, nil
+	}
+
+	for _, ty := range sizedTypes {
+		suffix, ok := strings.CutPrefix(lower, string(ty))
+		if !ok {
+			continue
+		}
+
+		size, err := strconv.Atoi(suffix)
+		if err != nil || size <= 0 || strings.HasPrefix(suffix, "0") || strings.HasPrefix(suffix, "+") {
+			break
+		}
+
+		return Element{Type: ty, Size: size}, nil
+	}
+
+	return Element{}, fmt.Errorf("unknown type %q", name)
+}
+
+// element has the same fields as Element without its custom unmarshallers
+type element Element
+
+// UnmarshalJSON decodes an Element and normalizes type aliases into Type, Size and Children
+// A bare JSON string is decoded as a type name, e.g. `"uint256[]"`
+func (e *Element) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '"' {
+		var name string
+		if err := json.Unmarshal(trimmed, &name); err != nil {
+			return err
+		}
+		return e.UnmarshalText([]byte(name))
+	}
+
+	var raw element
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	parsed, err := normalizeType(Element(raw))
+	if err != nil {
+		return err
+	}
+
+	*e = parsed
+	return nil
+}
+
+// normalizeType resolves the type name of the element, e.g. `uint256` or `tuple`, into Type, Size and Children
+// Returns an error if the type is unknown, its size conflicts with Size or Size is set on an array type name
+func normalizeType(elem Element) (Element, error) {
+	name := strings.TrimSpace(string(elem.Type))
+	parsed, err := parseType(name, elem.Children)
+	if err != nil {
+		return Element{}, err
+	}
+
+	switch {
+	case elem.Size == 0:
+	case strings.HasSuffix(name, "]"):
+		return Element{}, fmt.Errorf("size %d cannot be applied to array type %q", elem.Size, elem.Type)
+	case parsed.Size != 0 && elem.Size != parsed.Size:
+		return Element{}, fmt.Errorf("size %d conflicts with type %q", elem.Size, elem.Type)
+	default:
+		parsed.Size = elem.Size
+	}
+
+	parsed.Name = elem.Name
+	parsed.Optional = elem.Optional
+	parsed.Indexed = elem.Indexed
+	parsed.Default = elem.Default
+	return parsed, nil
+}
+
+// UnmarshalText decodes a type name such as `uint256`, `bytes32` or `address[]` into the Element
+func (e *Element) UnmarshalText(text []byte) error {
+	parsed, err := ParseType(string(text))
+	if err != nil {
+		return err
+	}
+
+	*e = parsed
+	return nil
+}
@@ -0,0 +1,240 @@
+package welder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ideatru/welder/ether"
+	"github.com/ideatru/welder/internal/utils"
+	"github.com/ideatru/welder/types"
+)
+
+var bigIntTy = reflect.TypeOf(big.NewInt(0))
+
+// weldPayload decodes the JSON array payload and assigns its items to the values built for the schema
+// Values are pointers to the types built for the elements, the same as Builder.Builds
+func weldPayload(schema types.Elements, data []byte, values []any) error {
+	root, err := utils.DecodeJSON(data)
+	if err != nil {
+		return err
+	}
+
+	items, ok := root.([]any)
+	if !ok {
+		return fmt.Errorf("payload must be a JSON array")
+	}
+
+	if len(items) > len(schema) {
+		return fmt.Errorf("payload has %d values, schema only declares %d", len(items), len(schema))
+	}
+
+	for i, elem := range schema {
+		var item any
+		if i < len(items) {
+			item = items[i]
+		}
+
+		if err := weld(elem, item, utils.JSONPointer("", i), reflect.ValueOf(values[i]).Elem()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// weld assigns a decoded JSON value to the target according to its element
+// Integers are range checked against the element size and signedness before assignment
+// Missing values are replaced with the element default when one is declared
+// Optional elements left null stay nil, while required elements must hold a value
+// Leaf values not handled here are delegated to the target's own JSON unmarshaller
+func weld(elem types.Element, value any, path string, target reflect.Value) error {
+	if value == nil && elem.Default != nil {
+		def, err := utils.DecodeJSON(elem.Default)
+		if err != nil {
+			return weldError(elem, path, ether.CodeInvalidSchema, nil, "invalid default for %s: %v", label(elem), err)
+		}
+		value = def
+	}
+
+	if elem.Optional && target.Kind() == reflect.Pointer {
+		if value == nil {
+			return nil
+		}
+
+		target.Set(reflect.New(target.Type().Elem()))
+		target = target.Elem()
+	}
+
+	if value == nil {
+		return missing(elem, path)
+	}
+
+	switch elem.Type {
+	case types.Int, types.Uint:
+		return weldNumber(elem, value, path, target)
+	case types.Bytes:
+		if (target.Kind() == reflect.Array || target.Kind() == reflect.Slice) && target.Type().Elem().Kind() == reflect.Uint8 {
+			return weldBytes(elem, value, path, target)
+		}
+	case types.Array:
+		return weldArray(elem, value, path, target)
+	case types.Object:
+		return weldObject(elem, value, path, target)
+	}
+
+	return weldJSON(elem, value, path, target)
+}
+
+// weldNumber parses an integer, checks that it fits the element and assigns it to the target
+// Integers are parsed by utils.ParseInteger, the same way Validate and the encoders parse them
+func weldNumber(elem types.Element, value any, path string, target reflect.Value) error {
+	number, err := utils.ParseInteger(elem, value)
+	var ierr *utils.IntegerError
+	if errors.As(err, &ierr) {
+		switch ierr.Code {
+		case utils.IntegerMismatch:
+			return weldError(elem, path, ether.CodeTypeMismatch, value, "expected an integer for %s", label(elem))
+		case utils.IntegerInvalidFormat:
+			return weldError(elem, path, ether.CodeInvalidFormat, value, "%s must be an integer without fraction or exponent", label(elem))
+		case utils.IntegerOutOfRange:
+			return weldError(elem, path, ether.CodeOutOfRange, value, "value %s overflows %s (range %s to %s)", number, label(elem), ierr.Min, ierr.Max)
+		}
+		return weldError(elem, path, ether.CodeInvalidSchema, nil, "%s: %v", label(elem), ierr)
+	}
+
+	switch {
+	case target.Type() == bigIntTy:
+		target.Set(reflect.ValueOf(number))
+	case target.CanInt() && number.IsInt64() && !target.OverflowInt(number.Int64()):
+		target.SetInt(number.Int64())
+	case target.CanUint() && number.IsUint64() && !target.OverflowUint(number.Uint64()):
+		target.SetUint(number.Uint64())
+	default:
+		return weldError(elem, path, ether.CodeOutOfRange, value, "value %s does not fit %s of %s", number, target.Type(), label(elem))
+	}
+
+	return nil
+}
+
+// weldBytes assigns 0x-prefixed hex to a byte slice or a fixed-size byte array
+// Arrays of byte numbers keep being decoded by the JSON unmarshaller
+func weldBytes(elem types.Element, value any, path string, target reflect.Value) error {
+	s, ok := value.(string)
+	if !ok {
+		return weldJSON(elem, value, path, target)
+	}
+
+	decoded, err := hexutil.Decode(s)
+	if err != nil {
+		return weldError(elem, path, ether.CodeInvalidFormat, value, "invalid hex for %s: %v", label(elem), err)
+	}
+
+	if target.Kind() == reflect.Slice {
+		target.SetBytes(decoded)
+		return nil
+	}
+
+	if len(decoded) != target.Len() {
+		return weldError(elem, path, ether.CodeInvalidLength, value, "expected %d bytes for %s, got %d", target.Len(), label(elem), len(decoded))
+	}
+
+	reflect.Copy(target, reflect.ValueOf(decoded))
+	return nil
+}
+
+// weldArray welds every item of a JSON array into a slice or a fixed-size array
+func weldArray(elem types.Element, value any, path string, target reflect.Value) error {
+	items, ok := value.([]any)
+	if !ok || len(elem.Children) != 1 {
+		return weldJSON(elem, value, path, target)
+	}
+
+	if elem.Size > 0 && len(items) > elem.Size {
+		return weldError(elem, path, ether.CodeInvalidLength, nil, "expected %d items for %s, got %d", elem.Size, label(elem), len(items))
+	}
+
+	// Missing slots of fixed-size arrays are filled with the child default
+	if elem.Size > 0 && len(items) < elem.Size {
+		items = append(items, make([]any, elem.Size-len(items))...)
+	}
+
+	switch target.Kind() {
+	case reflect.Slice:
+		target.Set(reflect.MakeSlice(target.Type(), len(items), len(items)))
+	case reflect.Array:
+		if target.Len() != len(items) {
+			return weldError(elem, path, ether.CodeInvalidLength, nil, "expected %d items for %s, got %d", target.Len(), label(elem), len(items))
+		}
+	default:
+		return weldJSON(elem, value, path, target)
+	}
+
+	for i, item := range items {
+		if err := weld(elem.Children[0], item, utils.JSONPointer(path, i), target.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// weldObject welds the fields of a JSON object into the struct built for the element
+// The struct fields are expected in the same order as the element's children
+func weldObject(elem types.Element, value any, path string, target reflect.Value) error {
+	fields, ok := value.(map[string]any)
+	if !ok || target.Kind() != reflect.Struct || target.NumField() != len(elem.Children) {
+		return weldJSON(elem, value, path, target)
+	}
+
+	for i, child := range elem.Children {
+		if err := weld(child, fields[child.Name], utils.JSONPointer(path, child.Name), target.Field(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// weldJSON re-encodes the value and delegates to the JSON unmarshaller of the target type
+func weldJSON(elem types.Element, value any, path string, target reflect.Value) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, target.Addr().Interface()); err != nil {
+		return weldError(elem, path, ether.CodeTypeMismatch, value, "invalid value for %s: %v", label(elem), err)
+	}
+
+	return nil
+}
+
+// label describes an element in error messages, e.g. `uint8 "amount"`
+func label(elem types.Element) string {
+	if elem.Name == "" {
+		return utils.TypeName(elem)
+	}
+
+	return fmt.Sprintf("%s %q", utils.TypeName(elem), elem.Name)
+}
+
+// missing creates the error returned when a required value is absent or null
+func missing(elem types.Element, path string) error {
+	return weldError(elem, path, ether.CodeRequired, nil, "%s is required", label(elem))
+}
+
+// weldError creates an ether.ValidationError describing why a value could not be welded
+func weldError(elem types.Element, path string, code ether.ValidationCode, value any, format string, args ...any) error {
+	return ether.ValidationError{
+		Path:     path,
+		Code:     code,
+		Expected: elem.Type,
+		Size:     elem.Size,
+		Value:    value,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}